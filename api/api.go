@@ -5,8 +5,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
+	"sync/atomic"
 	"text/template"
 	"time"
 
@@ -20,70 +24,85 @@ const (
 	productionServerGlobal = "global.api.betradar.com"
 )
 
+// RequestTimeout default timeout for the api calls
 var RequestTimeout = 32 * time.Second
 
 type API struct {
-	server  string
-	token   string
-	exitSig context.Context
-	client  *retryablehttp.Client
+	// first for 64-bit alignment of atomic operations
+	// server clock offset in nanoseconds, set on each whoami call
+	serverTimeOffset int64
+	// *uof.BookmakerDetails of the last whoami call
+	bookmaker atomic.Value
+
+	server         string
+	token          string
+	exitSig        context.Context
+	client         *retryablehttp.Client
+	limiter        *rateLimiter
+	inFlight       *inFlight
+	requestTimeout time.Duration
+	middleware     []Middleware
+	handler        Handler
 }
 
 // Dial connect to the staging or production api environment
-func Dial(ctx context.Context, env uof.Environment, token string) (*API, error) {
+func Dial(ctx context.Context, env uof.Environment, token string, opts ...Option) (*API, error) {
 	switch env {
 	case uof.Replay:
-		return Staging(ctx, token)
+		return Staging(ctx, token, opts...)
 	case uof.Staging:
-		return Staging(ctx, token)
+		return Staging(ctx, token, opts...)
 	case uof.Production:
-		return Production(ctx, token)
+		return Production(ctx, token, opts...)
 	case uof.ProductionGlobal:
-		return ProductionGlobal(ctx, token)
+		return ProductionGlobal(ctx, token, opts...)
 	default:
 		return nil, uof.Notice("queue dial", fmt.Errorf("unknown environment %d", env))
 	}
 }
 
 // Staging connects to the staging system
-func Staging(exitSig context.Context, token string) (*API, error) {
-	a := &API{
-		server:  stagingServer,
-		token:   token,
-		exitSig: exitSig,
-		client:  client(),
-	}
+func Staging(exitSig context.Context, token string, opts ...Option) (*API, error) {
+	a := newAPI(exitSig, stagingServer, token, opts...)
 	return a, a.Ping()
 }
 
 // Production connects to the production system
-func Production(exitSig context.Context, token string) (*API, error) {
-	a := &API{
-		server:  productionServer,
-		token:   token,
-		exitSig: exitSig,
-		client:  client(),
-	}
+func Production(exitSig context.Context, token string, opts ...Option) (*API, error) {
+	a := newAPI(exitSig, productionServer, token, opts...)
 	return a, a.Ping()
 }
 
 // Production connects to the production system
-func ProductionGlobal(exitSig context.Context, token string) (*API, error) {
+func ProductionGlobal(exitSig context.Context, token string, opts ...Option) (*API, error) {
+	a := newAPI(exitSig, productionServerGlobal, token, opts...)
+	return a, a.Ping()
+}
+
+func newAPI(exitSig context.Context, server, token string, opts ...Option) *API {
 	a := &API{
-		server:  productionServerGlobal,
-		token:   token,
-		exitSig: exitSig,
-		client:  client(),
+		server:         server,
+		token:          token,
+		exitSig:        exitSig,
+		limiter:        newRateLimiter(),
+		inFlight:       newInFlight(),
+		requestTimeout: RequestTimeout,
 	}
-	return a, a.Ping()
+	a.client = client(a.limiter)
+	for _, o := range opts {
+		o(a)
+	}
+	a.handler = chain(a.do, a.middleware)
+	return a
 }
 
-func client() *retryablehttp.Client {
+func client(l *rateLimiter) *retryablehttp.Client {
 	c := retryablehttp.NewClient()
 	c.Logger = nil
 	c.RetryWaitMin = 1 * time.Second
 	c.RetryWaitMax = 16 * time.Second
 	c.RetryMax = 4
+	c.Backoff = l.backoff
 	return c
 }
 
@@ -125,11 +144,56 @@ func (a *API) RequestFullOddsRecovery(producer uof.Producer, requestID int) erro
 // 	return a.post(fmt.Sprintf("/v1/%s/stateful_messages/events/%s/initiate_request", product, eventID))
 // }
 
+// Ping checks that the api is reachable and the token valid.
 func (a *API) Ping() error {
-	_, err := a.get(ping, nil)
+	_, err := a.BookmakerDetails()
 	return err
 }
 
+// BookmakerDetails describes bookmaker for which the token is issued. Also
+// refreshes ServerTimeOffset and LastBookmakerDetails.
+func (a *API) BookmakerDetails() (*uof.BookmakerDetails, error) {
+	requestedAt := time.Now()
+	buf, header, err := a.httpRequest(ping, nil, "GET")
+	if err != nil {
+		return nil, err
+	}
+	var bd uof.BookmakerDetails
+	if err := xml.Unmarshal(buf, &bd); err != nil {
+		return nil, uof.Notice("unmarshal", err)
+	}
+	if serverTime, err := http.ParseTime(header.Get("Date")); err == nil {
+		// compare server time with the middle of the request duration
+		localTime := requestedAt.Add(time.Since(requestedAt) / 2)
+		bd.ServerTimeOffset = serverTime.Sub(localTime)
+		atomic.StoreInt64(&a.serverTimeOffset, int64(bd.ServerTimeOffset))
+	}
+	last := bd
+	a.bookmaker.Store(&last)
+	return &bd, nil
+}
+
+// LastBookmakerDetails returns details received on the last BookmakerDetails
+// call, Dial makes the first one. Nil if there was no successful call.
+func (a *API) LastBookmakerDetails() *uof.BookmakerDetails {
+	bd, _ := a.bookmaker.Load().(*uof.BookmakerDetails)
+	return bd
+}
+
+// Stats returns counters of the api calls.
+func (a *API) Stats() Stats {
+	s := a.limiter.stats()
+	s.InFlightHits, s.InFlightMisses = a.inFlight.stats()
+	return s
+}
+
+// ServerTimeOffset difference between api server clock and the local clock
+// measured on the last BookmakerDetails call. Positive if the server clock is
+// ahead.
+func (a *API) ServerTimeOffset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&a.serverTimeOffset))
+}
+
 func (a *API) getAs(o interface{}, tpl string, p *params) error {
 	buf, err := a.get(tpl, p)
 	if err != nil {
@@ -142,57 +206,117 @@ func (a *API) getAs(o interface{}, tpl string, p *params) error {
 }
 
 // make http get request
+// Concurrent requests for the same path share one http call.
 func (a *API) get(tpl string, p *params) ([]byte, error) {
-	return a.httpRequest(tpl, p, "GET")
+	return a.inFlight.do(runTemplate(tpl, p), func() ([]byte, error) {
+		buf, _, err := a.httpRequest(tpl, p, "GET")
+		return buf, err
+	})
 }
 
 // make http put request
 func (a *API) put(tpl string, p *params) error {
-	_, err := a.httpRequest(tpl, p, "PUT")
+	_, _, err := a.httpRequest(tpl, p, "PUT")
 	return err
 }
 
 // make http post request
 func (a *API) post(tpl string, p *params) error {
-	_, err := a.httpRequest(tpl, p, "POST")
+	_, _, err := a.httpRequest(tpl, p, "POST")
 	return err
 }
 
-func (a *API) httpRequest(tpl string, p *params, method string) ([]byte, error) {
+func (a *API) httpRequest(tpl string, p *params, method string) ([]byte, http.Header, error) {
+	var buf []byte
+	var header http.Header
+	err := a.request(tpl, p, method, func(resp *http.Response) error {
+		var err error
+		buf, err = ioutil.ReadAll(resp.Body)
+		header = resp.Header
+		return err
+	})
+	return buf, header, err
+}
+
+// getStream makes http get request and decodes response body while it is
+// being received. Unlike get concurrent requests are not coalesced.
+func (a *API) getStream(tpl string, p *params, decode func(io.Reader) error) error {
+	return a.request(tpl, p, "GET", func(resp *http.Response) error {
+		if err := decode(resp.Body); err != nil {
+			return uof.Notice("unmarshal", err)
+		}
+		return nil
+	})
+}
+
+// request makes http request, on successful response calls read with the
+// response
+func (a *API) request(tpl string, p *params, method string, read func(*http.Response) error) error {
 	path := runTemplate(tpl, p)
 	url := fmt.Sprintf("https://%s%s", a.server, path)
 
-	req, err := retryablehttp.NewRequest(method, url, nil)
-	if err != nil {
-		return nil, uof.E("http.NewRequest", uof.APIError{URL: url, Inner: err})
+	if err := a.limiter.wait(a.exitSig, path); err != nil {
+		return uof.E("rateLimit", uof.APIError{URL: url, Inner: err})
+	}
+	c := &Call{
+		Method:   method,
+		Endpoint: tpl,
+		URL:      url,
+		Header:   make(http.Header),
+		ctx:      a.exitSig,
+	}
+	if p != nil {
+		c.Lang = p.Lang
 	}
 	if a.exitSig != nil {
-		ctx, cancel := context.WithTimeout(a.exitSig, RequestTimeout)
+		ctx, cancel := context.WithTimeout(a.exitSig, a.requestTimeout)
 		defer cancel()
-		req = req.WithContext(ctx)
+		c.ctx = ctx
 	}
 
-	req.Header.Set("x-access-token", a.token)
-	resp, err := a.client.Do(req)
+	resp, err := a.handler(c)
 	if err != nil {
-		return nil, uof.E("client.Do", uof.APIError{URL: url, Inner: err})
+		return err
 	}
-
 	defer resp.Body.Close()
-	buf, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, uof.E("http.Body", uof.APIError{URL: url, Inner: err})
-	}
 
 	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
-		return nil, uof.E("http.StatusCode", uof.APIError{URL: url, StatusCode: resp.StatusCode, Response: string(buf)})
+		buf, _ := ioutil.ReadAll(resp.Body)
+		return uof.E("http.StatusCode", uof.APIError{URL: url, StatusCode: resp.StatusCode, Response: string(buf)})
 	}
+	if err := read(resp); err != nil {
+		var ue uof.Error
+		if errors.As(err, &ue) {
+			return err
+		}
+		return uof.E("http.Body", uof.APIError{URL: url, Inner: err})
+	}
+	return nil
+}
 
-	return buf, nil
+// do is the innermost Handler, makes http request
+func (a *API) do(c *Call) (*http.Response, error) {
+	req, err := retryablehttp.NewRequest(c.Method, c.URL, nil)
+	if err != nil {
+		return nil, uof.E("http.NewRequest", uof.APIError{URL: c.URL, Inner: err})
+	}
+	if c.ctx != nil {
+		req = req.WithContext(c.ctx)
+	}
+	for k, v := range c.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("x-access-token", a.token)
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, uof.E("client.Do", uof.APIError{URL: c.URL, Inner: err})
+	}
+	return resp, nil
 }
 
 type params struct {
 	EventURN           uof.URN
+	LotteryURN         uof.URN
 	ScenarioID         int
 	Speed              int
 	MaxDelay           int
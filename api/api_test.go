@@ -5,6 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"testing"
 	"time"
@@ -121,3 +125,23 @@ func testFixtures(t *testing.T, a *API) {
 		}
 	}()
 }
+
+func TestLastBookmakerDetails(t *testing.T) {
+	buf, err := ioutil.ReadFile("../testdata/bookmaker_details.xml")
+	assert.NoError(t, err)
+	calls := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write(buf)
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	a := newAPI(context.Background(), u.Host, "token", WithTransport(ts.Client().Transport))
+
+	assert.Nil(t, a.LastBookmakerDetails())
+	assert.NoError(t, a.Ping())
+	bd := a.LastBookmakerDetails()
+	assert.NotNil(t, bd)
+	assert.Equal(t, 1234, bd.BookmakerID)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/minus5/go-uof-sdk"
+)
+
+// Call is a single api request passed through the middleware chain.
+type Call struct {
+	Method   string
+	Endpoint string // path template, without parameters
+	URL      string
+	Lang     uof.Lang
+	// Additional request headers. Middleware can set them before calling the
+	// next handler.
+	Header http.Header
+
+	ctx context.Context
+}
+
+// Handler makes the api call.
+type Handler func(c *Call) (*http.Response, error)
+
+// Middleware wraps Handler to add behavior before or after the api call.
+type Middleware func(next Handler) Handler
+
+// CallInfo describes finished api call.
+type CallInfo struct {
+	Method     string        `json:"method"`
+	Endpoint   string        `json:"endpoint"`
+	URL        string        `json:"url"`
+	Lang       uof.Lang      `json:"lang,omitempty"`
+	StatusCode int           `json:"statusCode,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	Err        error         `json:"-"`
+}
+
+// Observe is middleware which reports each api call to the fn. Useful for
+// request logging or latency metrics.
+func Observe(fn func(CallInfo)) Middleware {
+	return func(next Handler) Handler {
+		return func(c *Call) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(c)
+			ci := CallInfo{
+				Method:   c.Method,
+				Endpoint: c.Endpoint,
+				URL:      c.URL,
+				Lang:     c.Lang,
+				Duration: time.Since(start),
+				Err:      err,
+			}
+			if resp != nil {
+				ci.StatusCode = resp.StatusCode
+			}
+			fn(ci)
+			return resp, err
+		}
+	}
+}
+
+// chain wraps h with middleware, first one is the outermost
+func chain(h Handler, mw []Middleware) Handler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
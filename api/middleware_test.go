@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/minus5/go-uof-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "my-token", r.Header.Get("x-access-token"))
+		assert.Equal(t, "test", r.Header.Get("x-middleware"))
+		_, _ = w.Write([]byte(`<player_profile><player id="sr:player:947" full_name="Lee Barnard"/></player_profile>`))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+
+	var calls []CallInfo
+	setHeader := func(next Handler) Handler {
+		return func(c *Call) (*http.Response, error) {
+			c.Header.Set("x-middleware", "test")
+			return next(c)
+		}
+	}
+	a := newAPI(context.Background(), u.Host, "my-token",
+		WithTransport(ts.Client().Transport),
+		WithRequestTimeout(time.Second),
+		WithMiddleware(
+			Observe(func(ci CallInfo) { calls = append(calls, ci) }),
+			setHeader,
+		),
+	)
+	p, err := a.Player(uof.LangEN, 947)
+	assert.NoError(t, err)
+	assert.Equal(t, "Lee Barnard", p.FullName)
+
+	assert.Len(t, calls, 1)
+	c := calls[0]
+	assert.Equal(t, "GET", c.Method)
+	assert.Equal(t, pathPlayer, c.Endpoint)
+	assert.Equal(t, uof.LangEN, c.Lang)
+	assert.Equal(t, http.StatusOK, c.StatusCode)
+	assert.NoError(t, c.Err)
+	assert.Equal(t, time.Second, a.requestTimeout)
+}
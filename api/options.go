@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option sets attributes on the API.
+type Option func(*API)
+
+// WithTransport replaces http transport used for api calls.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(a *API) {
+		a.client.HTTPClient.Transport = rt
+	}
+}
+
+// WithProxy routes api calls through the outbound proxy. Applies only to the
+// default transport or to the *http.Transport set by WithTransport.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(a *API) {
+		if t, ok := a.client.HTTPClient.Transport.(*http.Transport); ok {
+			t.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+}
+
+// WithRetry sets number of retries and the min and max wait between them.
+func WithRetry(retryMax int, waitMin, waitMax time.Duration) Option {
+	return func(a *API) {
+		a.client.RetryMax = retryMax
+		a.client.RetryWaitMin = waitMin
+		a.client.RetryWaitMax = waitMax
+	}
+}
+
+// WithRequestTimeout sets timeout for a single api call, including retries.
+// Default is RequestTimeout.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(a *API) {
+		a.requestTimeout = d
+	}
+}
+
+// WithRateLimit overrides default rate limit for the class of endpoints.
+func WithRateLimit(class EndpointClass, l RateLimit) Option {
+	return func(a *API) {
+		a.limiter.limits[class] = l
+	}
+}
+
+// WithMiddleware appends middleware to the chain through which each api call
+// is passed. First added is the outermost.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(a *API) {
+		a.middleware = append(a.middleware, mw...)
+	}
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// EndpointClass groups api endpoints which share the same access restrictions.
+type EndpointClass int8
+
+const (
+	EndpointOther EndpointClass = iota
+	EndpointRecovery
+	EndpointSports
+	EndpointDescriptions
+)
+
+func (c EndpointClass) String() string {
+	switch c {
+	case EndpointRecovery:
+		return "recovery"
+	case EndpointSports:
+		return "sports"
+	case EndpointDescriptions:
+		return "descriptions"
+	default:
+		return "other"
+	}
+}
+
+func endpointClass(path string) EndpointClass {
+	switch {
+	case strings.Contains(path, "/recovery/"):
+		return EndpointRecovery
+	case strings.HasPrefix(path, "/v1/sports/"), strings.HasPrefix(path, "/v1/wns/"):
+		return EndpointSports
+	case strings.HasPrefix(path, "/v1/descriptions/"):
+		return EndpointDescriptions
+	default:
+		return EndpointOther
+	}
+}
+
+// RateLimit allows Requests in each Per interval.
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// RateLimits for each class of endpoints, used when API is created. When
+// exceeded Betradar responds with 429 Too Many Requests.
+// Recovery limit is applied for each producer separately, it follows the
+// recovery access restrictions:
+// https://docs.betradar.com/display/BD/UOF+-+Access+restrictions+for+odds+recovery
+// Sports and descriptions limits are conservative client side defaults, not
+// Betradar values; adjust them to the limits of your bookmaker account.
+var RateLimits = map[EndpointClass]RateLimit{
+	EndpointRecovery:     {Requests: 4, Per: 2 * time.Minute},
+	EndpointSports:       {Requests: 50, Per: time.Second},
+	EndpointDescriptions: {Requests: 20, Per: time.Second},
+}
+
+// Stats counters of the api calls.
+type Stats struct {
+	// calls delayed by the client side rate limiter
+	Throttled int64 `json:"throttled"`
+	// 429 Too Many Requests responses received
+	TooManyRequests int64 `json:"tooManyRequests"`
+	// get requests which joined the same request already in progress
+	InFlightHits int64 `json:"inFlightHits"`
+	// get requests which made new http call
+	InFlightMisses int64 `json:"inFlightMisses"`
+}
+
+// token bucket
+type bucket struct {
+	rate        float64 // tokens per second
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	sync.Mutex
+}
+
+func newBucket(l RateLimit) *bucket {
+	return &bucket{
+		rate:   float64(l.Requests) / l.Per.Seconds(),
+		burst:  float64(l.Requests),
+		tokens: float64(l.Requests),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes one token and returns how long the caller has to wait before
+// using it.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.Lock()
+	defer b.Unlock()
+
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	if p := b.pausedUntil.Sub(now); p > wait {
+		wait = p
+	}
+	return wait
+}
+
+// pause stops issuing tokens until t
+func (b *bucket) pause(t time.Time) {
+	b.Lock()
+	defer b.Unlock()
+	if t.After(b.pausedUntil) {
+		b.pausedUntil = t
+	}
+}
+
+type rateLimiter struct {
+	// counters first for 64-bit alignment of atomic operations
+	throttled       int64
+	tooManyRequests int64
+
+	limits  map[EndpointClass]RateLimit
+	buckets map[string]*bucket
+	sync.Mutex
+}
+
+func newRateLimiter() *rateLimiter {
+	limits := make(map[EndpointClass]RateLimit)
+	for c, l := range RateLimits {
+		limits[c] = l
+	}
+	return &rateLimiter{
+		limits:  limits,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// bucket for the path, nil if path is not limited
+func (l *rateLimiter) bucket(path string) *bucket {
+	class := endpointClass(path)
+	limit, ok := l.limits[class]
+	if !ok || limit.Requests <= 0 || limit.Per <= 0 {
+		return nil
+	}
+	key := class.String()
+	if class == EndpointRecovery {
+		// recovery is limited per producer: /v1/{producer}/recovery/...
+		key = key + strings.SplitN(path, "/recovery/", 2)[0]
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newBucket(limit)
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// wait blocks until request to the path is allowed
+func (l *rateLimiter) wait(ctx context.Context, path string) error {
+	b := l.bucket(path)
+	if b == nil {
+		return nil
+	}
+	d := b.reserve(time.Now())
+	if d <= 0 {
+		return nil
+	}
+	atomic.AddInt64(&l.throttled, 1)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// backoff is retryablehttp.Backoff which on 429 response pauses all requests of
+// the same class for the Retry-After interval.
+func (l *rateLimiter) backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		atomic.AddInt64(&l.tooManyRequests, 1)
+		if d, ok := retryAfter(resp); ok {
+			if resp.Request != nil {
+				if b := l.bucket(resp.Request.URL.Path); b != nil {
+					b.pause(time.Now().Add(d))
+				}
+			}
+			return d
+		}
+	}
+	return retryablehttp.DefaultBackoff(min, max, attemptNum, resp)
+}
+
+func (l *rateLimiter) stats() Stats {
+	return Stats{
+		Throttled:       atomic.LoadInt64(&l.throttled),
+		TooManyRequests: atomic.LoadInt64(&l.tooManyRequests),
+	}
+}
+
+// retryAfter parses Retry-After header, which is in seconds or http date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
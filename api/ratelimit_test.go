@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointClass(t *testing.T) {
+	data := []struct {
+		path  string
+		class EndpointClass
+	}{
+		{runTemplate(recovery, &params{Producer: 1}), EndpointRecovery},
+		{runTemplate(pathFixture, &params{EventURN: "sr:match:1"}), EndpointSports},
+		{runTemplate(pathDrawFixture, &params{EventURN: "wns:draw:1"}), EndpointSports},
+		{runTemplate(pathMarkets, &params{}), EndpointDescriptions},
+		{ping, EndpointOther},
+		{replayReset, EndpointOther},
+	}
+	for _, d := range data {
+		assert.Equal(t, d.class, endpointClass(d.path), d.path)
+	}
+}
+
+func TestBucket(t *testing.T) {
+	b := newBucket(RateLimit{Requests: 2, Per: time.Second})
+	now := b.last
+	assert.Equal(t, time.Duration(0), b.reserve(now))
+	assert.Equal(t, time.Duration(0), b.reserve(now))
+	assert.Equal(t, 500*time.Millisecond, b.reserve(now))
+	// refilled after a second
+	now = now.Add(1500 * time.Millisecond)
+	assert.Equal(t, time.Duration(0), b.reserve(now))
+
+	b.pause(now.Add(time.Minute))
+	assert.Equal(t, time.Minute, b.reserve(now))
+}
+
+func TestRateLimiterRecoveryPerProducer(t *testing.T) {
+	l := newRateLimiter()
+	l.limits[EndpointRecovery] = RateLimit{Requests: 1, Per: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, l.wait(ctx, runTemplate(recovery, &params{Producer: 1})))
+	assert.NoError(t, l.wait(ctx, runTemplate(recovery, &params{Producer: 3})))
+	// second request for the same producer waits
+	assert.Error(t, l.wait(ctx, runTemplate(recovery, &params{Producer: 1})))
+	assert.Equal(t, int64(1), l.stats().Throttled)
+}
+
+func TestRateLimiterRetryAfter(t *testing.T) {
+	l := newRateLimiter()
+	u, _ := url.Parse("https://api.betradar.com" + runTemplate(pathFixture, &params{EventURN: "sr:match:1"}))
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"3"}},
+		Request:    &http.Request{URL: u},
+	}
+	assert.Equal(t, 3*time.Second, l.backoff(time.Second, 16*time.Second, 0, resp))
+	assert.Equal(t, int64(1), l.stats().TooManyRequests)
+
+	// other sports calls are paused
+	d := l.bucket(u.Path).reserve(time.Now())
+	assert.True(t, d > 2*time.Second)
+}
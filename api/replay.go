@@ -16,14 +16,9 @@ const (
 )
 
 // Replay service for unified feed methods
-func Replay(exitSig context.Context, token string) (*ReplayAPI, error) {
+func Replay(exitSig context.Context, token string, opts ...Option) (*ReplayAPI, error) {
 	r := &ReplayAPI{
-		api: &API{
-			server:  productionServer,
-			token:   token,
-			exitSig: exitSig,
-			client:  client(),
-		},
+		api: newAPI(exitSig, productionServer, token, opts...),
 	}
 	return r, r.Reset()
 }
@@ -0,0 +1,26 @@
+package api
+
+import "github.com/minus5/go-uof-sdk/internal/inflight"
+
+// inFlight coalesces concurrent requests for the same key into single call.
+// Callers which arrive while the call is in progress wait for it and share
+// its result.
+type inFlight struct {
+	g *inflight.Group
+}
+
+func newInFlight() *inFlight {
+	return &inFlight{g: inflight.New()}
+}
+
+func (f *inFlight) do(key string, fn func() ([]byte, error)) ([]byte, error) {
+	v, err := f.g.Do(key, func() (interface{}, error) {
+		return fn()
+	})
+	buf, _ := v.([]byte)
+	return buf, err
+}
+
+func (f *inFlight) stats() (hits, misses int64) {
+	return f.g.Stats()
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInFlight(t *testing.T) {
+	f := newInFlight()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls int32
+	fn := func() ([]byte, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+		}
+		<-release
+		return []byte("fixture"), nil
+	}
+	key := "/v1/sports/en/sport_events/sr:match:1/fixture.xml"
+
+	var wg sync.WaitGroup
+	get := func() {
+		defer wg.Done()
+		buf, err := f.do(key, fn)
+		assert.NoError(t, err)
+		assert.Equal(t, "fixture", string(buf))
+	}
+	wg.Add(1)
+	go get()
+	<-started
+	wg.Add(3)
+	for i := 0; i < 3; i++ {
+		go get()
+	}
+	// wait for other callers to join the first one
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		if hits, _ := f.stats(); hits == 3 || time.Now().After(deadline) {
+			break
+		}
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	hits, misses := f.stats()
+	assert.Equal(t, int64(3), hits)
+	assert.Equal(t, int64(1), misses)
+
+	// finished calls are not cached
+	_, _ = f.do(key, fn)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"encoding/xml"
+	"io"
+	"net/url"
 	"time"
 
 	"github.com/minus5/go-uof-sdk"
@@ -14,17 +16,31 @@ const (
 	pathPlayer        = "/v1/sports/{{.Lang}}/players/sr:player:{{.PlayerID}}/profile.xml"
 	events            = "/v1/sports/{{.Lang}}/schedules/pre/schedule.xml?start={{.Start}}&limit={{.Limit}}"
 	liveEvents        = "/v1/sports/{{.Lang}}/schedules/live/schedule.xml"
+
+	// number of fixtures in one schedule page
+	scheduleLimit = 1000
 )
 
-// Markets all currently available markets for a language
+// Markets all currently available markets for a language.
+// Response is decoded while it is received, without buffering the whole
+// body, but all descriptions are still returned at once.
 func (a *API) Markets(lang uof.Lang) (uof.MarketDescriptions, error) {
-	var mr marketsRsp
-	return mr.Markets, a.getAs(&mr, pathMarkets, &params{Lang: lang})
+	var ms uof.MarketDescriptions
+	err := a.getStream(pathMarkets, &params{Lang: lang}, func(r io.Reader) error {
+		var err error
+		ms, err = decodeMarkets(r)
+		return err
+	})
+	return ms, err
 }
 
+// MarketVariant descriptions of the market variant.
+// Dynamic variants (pre:playerprops:<event>:<player>, pre:outcometext:<id>)
+// are fetched from the same variants path as the static ones (sr:...), there
+// is no separate endpoint for them. Variant is path segment so it is escaped.
 func (a *API) MarketVariant(lang uof.Lang, marketID int, variant string) (uof.MarketDescriptions, error) {
 	var mr marketsRsp
-	return mr.Markets, a.getAs(&mr, pathMarketVariant, &params{Lang: lang, MarketID: marketID, Variant: variant})
+	return mr.Markets, a.getAs(&mr, pathMarketVariant, &params{Lang: lang, MarketID: marketID, Variant: url.PathEscape(variant)})
 }
 
 // Fixture lists the fixture for a specified sport event
@@ -58,58 +74,57 @@ type fixtureRsp struct {
 	GeneratedAt time.Time   `xml:"generated_at,attr,omitempty" json:"generatedAt,omitempty"`
 }
 
-type scheduleRsp struct {
-	Fixtures    []uof.Fixture `xml:"sport_event,omitempty" json:"sportEvent,omitempty"`
-	GeneratedAt time.Time     `xml:"generated_at,attr,omitempty" json:"generatedAt,omitempty"`
-}
-
-// Fixtures gets all the fixtures with schedule before to
+// Fixtures gets all the fixtures with schedule before to.
+// Live schedule is fetched first, than the prematch schedule page by page.
+// Paging stops after the page with fixture scheduled after to, or at the
+// first empty page which marks the end of the schedule.
+// Each page is decoded whole before its fixtures are sent to the channel, so
+// a slow consumer does not hold the response body open until the request
+// timeout. Memory is bounded by the page size.
 func (a *API) Fixtures(lang uof.Lang, to time.Time) (<-chan uof.Fixture, <-chan error) {
 	errc := make(chan error, 1)
 	out := make(chan uof.Fixture)
 	go func() {
 		defer close(out)
 		defer close(errc)
-		done := false
 
-		parse := func(buf []byte) error {
-			var sr scheduleRsp
-			if err := xml.Unmarshal(buf, &sr); err != nil {
-				return uof.Notice("unmarshal", err)
-			}
-			for _, f := range sr.Fixtures {
+		page := func(tpl string, p *params) ([]uof.Fixture, error) {
+			var fs []uof.Fixture
+			err := a.getStream(tpl, p, func(r io.Reader) error {
+				return decodeFixtures(r, func(f uof.Fixture) {
+					fs = append(fs, f)
+				})
+			})
+			return fs, err
+		}
+		// returns true when there is no need for the next page
+		send := func(fs []uof.Fixture) bool {
+			done := len(fs) == 0
+			for _, f := range fs {
 				out <- f
 				if f.Scheduled.After(to) {
 					done = true
 				}
 			}
-			return nil
+			return done
 		}
 
 		// first live events
-		buf, err := a.get(liveEvents, &params{Lang: lang})
+		fs, err := page(liveEvents, &params{Lang: lang})
 		if err != nil {
 			errc <- err
 			return
 		}
-		if err := parse(buf); err != nil {
-			errc <- err
-			return
-		}
+		send(fs)
 
 		// than all events which has scheduled before to
-		limit := 1000
-		for start := 0; true; start += limit {
-			buf, err := a.get(events, &params{Lang: lang, Start: start, Limit: limit})
+		for start := 0; true; start += scheduleLimit {
+			fs, err := page(events, &params{Lang: lang, Start: start, Limit: scheduleLimit})
 			if err != nil {
 				errc <- err
 				return
 			}
-			if err := parse(buf); err != nil {
-				errc <- err
-				return
-			}
-			if done {
+			if send(fs) {
 				return
 			}
 		}
@@ -117,3 +132,49 @@ func (a *API) Fixtures(lang uof.Lang, to time.Time) (<-chan uof.Fixture, <-chan
 
 	return out, errc
 }
+
+// decodeMarkets builds market descriptions from the api response
+func decodeMarkets(r io.Reader) (uof.MarketDescriptions, error) {
+	var ms uof.MarketDescriptions
+	err := decodeEach(xml.NewDecoder(r), "market", func(d *xml.Decoder, start xml.StartElement) error {
+		var md uof.MarketDescription
+		if err := d.DecodeElement(&md, &start); err != nil {
+			return err
+		}
+		ms = append(ms, md)
+		return nil
+	})
+	return ms, err
+}
+
+// decodeFixtures calls each for every fixture while reading schedule api
+// response
+func decodeFixtures(r io.Reader, each func(uof.Fixture)) error {
+	return decodeEach(xml.NewDecoder(r), "sport_event", func(d *xml.Decoder, start xml.StartElement) error {
+		var f uof.Fixture
+		if err := d.DecodeElement(&f, &start); err != nil {
+			return err
+		}
+		each(f)
+		return nil
+	})
+}
+
+// decodeEach iterates over xml tokens and calls decode for each element with
+// the local name
+func decodeEach(d *xml.Decoder, name string, decode func(*xml.Decoder, xml.StartElement) error) error {
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if se, ok := t.(xml.StartElement); ok && se.Name.Local == name {
+			if err := decode(d, se); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minus5/go-uof-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeMarkets(t *testing.T) {
+	buf, err := ioutil.ReadFile("../testdata/markets-1.xml")
+	assert.NoError(t, err)
+
+	var mr marketsRsp
+	assert.NoError(t, xml.Unmarshal(buf, &mr))
+
+	ms, err := decodeMarkets(bytes.NewReader(buf))
+	assert.NoError(t, err)
+	assert.True(t, len(ms) > 0)
+	assert.Equal(t, mr.Markets, ms)
+
+	_, err = decodeMarkets(bytes.NewReader(buf[:len(buf)/2]))
+	assert.Error(t, err)
+}
+
+func TestDecodeFixtures(t *testing.T) {
+	buf, err := ioutil.ReadFile("../testdata/schedule-0.xml")
+	assert.NoError(t, err)
+
+	var fs []uof.Fixture
+	err = decodeFixtures(bytes.NewReader(buf), func(f uof.Fixture) {
+		fs = append(fs, f)
+	})
+	assert.NoError(t, err)
+	assert.Len(t, fs, 2)
+	assert.Equal(t, 18001015, fs[0].ID)
+	assert.Equal(t, "Ajax Amsterdam", fs[0].Home.Name)
+	assert.Equal(t, 18001017, fs[1].ID)
+	assert.Equal(t, 679, fs[1].Tournament.ID)
+}
+
+func BenchmarkMarketsUnmarshal(b *testing.B) {
+	buf, err := ioutil.ReadFile("../testdata/markets-1.xml")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// as it was done before; read whole body, than unmarshal
+		body, _ := ioutil.ReadAll(bytes.NewReader(buf))
+		var mr marketsRsp
+		if err := xml.Unmarshal(body, &mr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarketsDecode(b *testing.B) {
+	buf, err := ioutil.ReadFile("../testdata/markets-1.xml")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := decodeMarkets(bytes.NewReader(buf)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarketVariantPlayerProps(t *testing.T) {
+	buf, err := ioutil.ReadFile("../testdata/market_variant-0.xml")
+	assert.NoError(t, err)
+
+	var path string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		_, _ = w.Write(buf)
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	a := newAPI(context.Background(), u.Host, "token", WithTransport(ts.Client().Transport))
+
+	variant := "pre:playerprops:35432179:608000"
+	ms, err := a.MarketVariant(uof.LangEN, 768, variant)
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/descriptions/en/markets/768/variants/pre:playerprops:35432179:608000", path)
+
+	assert.Len(t, ms, 1)
+	m := ms[0]
+	assert.Equal(t, 768, m.ID)
+	assert.Equal(t, variant, m.Variant)
+	assert.NotEqual(t, 0, m.VariantID)
+	assert.Len(t, m.Outcomes, 2)
+	assert.Len(t, m.Specifiers, 2)
+
+	// outcome ids must match ids of the odds change outcomes
+	oc := []byte(`<odds_change product="3" event_id="sr:match:35432179" timestamp="1">
+		<odds>
+			<market id="768" specifiers="variant=pre:playerprops:35432179:608000|total=19.5">
+				<outcome id="sr:player:608000:12" odds="1.9"/>
+				<outcome id="sr:player:608000:13" odds="1.9"/>
+			</market>
+		</odds>
+	</odds_change>`)
+	var o uof.OddsChange
+	assert.NoError(t, xml.Unmarshal(oc, &o))
+	assert.Equal(t, m.Variant, o.Markets[0].Specifiers["variant"])
+	assert.NotEqual(t, m.Outcomes[0].ID, m.Outcomes[1].ID)
+	for i, oo := range o.Markets[0].Outcomes {
+		assert.Equal(t, m.Outcomes[i].ID, oo.ID)
+	}
+}
+
+func TestFixturesPaging(t *testing.T) {
+	schedule, err := ioutil.ReadFile("../testdata/schedule-0.xml")
+	assert.NoError(t, err)
+	empty := []byte(`<schedule generated_at="2019-08-20T12:35:11+00:00"></schedule>`)
+
+	var mu sync.Mutex
+	var starts []string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/sports/en/schedules/live/schedule.xml" {
+			_, _ = w.Write(empty)
+			return
+		}
+		start := r.URL.Query().Get("start")
+		mu.Lock()
+		starts = append(starts, start)
+		mu.Unlock()
+		if start == "0" {
+			_, _ = w.Write(schedule)
+			return
+		}
+		_, _ = w.Write(empty)
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	a := newAPI(context.Background(), u.Host, "token", WithTransport(ts.Client().Transport))
+
+	fixtures := func(to time.Time) []uof.Fixture {
+		starts = nil
+		in, errc := a.Fixtures(uof.LangEN, to)
+		var fs []uof.Fixture
+		for f := range in {
+			fs = append(fs, f)
+		}
+		assert.NoError(t, <-errc)
+		return fs
+	}
+
+	// all fixtures are before to, paging stops at the empty page
+	fs := fixtures(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, fs, 2)
+	assert.Equal(t, []string{"0", "1000"}, starts)
+
+	// fixture after to stops paging
+	fs = fixtures(time.Date(2019, 5, 9, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, fs, 2)
+	assert.Equal(t, []string{"0"}, starts)
+}
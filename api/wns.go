@@ -0,0 +1,33 @@
+package api
+
+import (
+	"github.com/minus5/go-uof-sdk"
+)
+
+// Numbers betting (WNS) endpoints
+const (
+	pathDrawFixture     = "/v1/wns/{{.Lang}}/sport_events/{{.EventURN}}/fixture.xml"
+	pathDrawSummary     = "/v1/wns/{{.Lang}}/sport_events/{{.EventURN}}/summary.xml"
+	pathLotterySchedule = "/v1/wns/{{.Lang}}/lotteries/{{.LotteryURN}}/schedule.xml"
+)
+
+// DrawFixture lists the fixture for a specified lottery draw
+func (a *API) DrawFixture(lang uof.Lang, eventURN uof.URN) ([]byte, error) {
+	return a.get(pathDrawFixture, &params{Lang: lang, EventURN: eventURN})
+}
+
+// DrawSummary lists the draw fixture with the draw result
+func (a *API) DrawSummary(lang uof.Lang, eventURN uof.URN) ([]byte, error) {
+	return a.get(pathDrawSummary, &params{Lang: lang, EventURN: eventURN})
+}
+
+// LotterySchedule lottery info with the upcoming draws
+func (a *API) LotterySchedule(lang uof.Lang, lotteryURN uof.URN) (*uof.Lottery, error) {
+	var rsp uof.LotteryScheduleRsp
+	if err := a.getAs(&rsp, pathLotterySchedule, &params{Lang: lang, LotteryURN: lotteryURN}); err != nil {
+		return nil, err
+	}
+	l := rsp.Lottery
+	l.Draws = rsp.Draws
+	return &l, nil
+}
@@ -16,9 +16,10 @@ type BetSettlement struct {
 }
 
 type BetSettlementMarket struct {
-	ID         int               `xml:"id,attr" json:"id"`
-	LineID     int               `json:"lineID"`
-	Specifiers map[string]string `json:"specifiers,omitempty"`
+	ID             int        `xml:"id,attr" json:"id"`
+	LineID         int        `json:"lineID"`
+	LineSpecifiers string     `xml:"-" json:"lineSpecifiers,omitempty"`
+	Specifiers     Specifiers `json:"specifiers,omitempty"`
 	// Describes the reason for voiding certain outcomes for a particular market.
 	// Only set if at least one of the outcomes have a void_factor. A list of void
 	// reasons can be found above this table or by using the API at
@@ -29,8 +30,11 @@ type BetSettlementMarket struct {
 }
 
 type BetSettlementOutcome struct {
-	ID             int           `json:"id"`
+	ID int `json:"id"`
+	// original outcome id when it is not integer
+	URN            string        `xml:"-" json:"urn,omitempty"`
 	PlayerID       int           `json:"playerID"`
+	CompetitorIDs  []int         `json:"competitorIDs,omitempty"`
 	Result         OutcomeResult `json:"result"`
 	DeadHeatFactor float64       `json:"deadHeatFactor,omitempty"`
 }
@@ -83,9 +87,14 @@ func (t *BetSettlementMarket) UnmarshalXML(d *xml.Decoder, start xml.StartElemen
 	}
 	t.Specifiers = toSpecifiers(overlay.Specifiers, overlay.ExtendedSpecifiers)
 	t.LineID = toLineID(overlay.Specifiers)
+	t.LineSpecifiers = canonicalSpecifiers(overlay.Specifiers)
 	return nil
 }
 
+func (m BetSettlementMarket) VariantSpecifier() string {
+	return m.Specifiers["variant"]
+}
+
 func (t *BetSettlementOutcome) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	type T BetSettlementOutcome
 	var overlay struct {
@@ -100,7 +109,9 @@ func (t *BetSettlementOutcome) UnmarshalXML(d *xml.Decoder, start xml.StartEleme
 		return err
 	}
 	t.ID = toOutcomeID(overlay.ID)
+	t.URN = toOutcomeURN(overlay.ID)
 	t.PlayerID = toPlayerID(overlay.ID)
+	t.CompetitorIDs = toCompetitorIDs(overlay.ID)
 	t.Result = toResult(overlay.Result, overlay.VoidFactor, overlay.DeadHeatFactor)
 	if t.Result == OutcomeResultWinWithDeadHead && overlay.DeadHeatFactor != nil {
 		t.DeadHeatFactor = *overlay.DeadHeatFactor
@@ -24,6 +24,14 @@ type BetStop struct {
 	MarketIDs []int        `json:"marketsIDs"`
 	Producer  Producer     `xml:"product,attr" json:"producer"`
 	Status    MarketStatus `json:"status,omitempty"`
+	// Open lines of the stopped markets, filled in the pipe.BetStop stage.
+	Lines []MarketLine `json:"lines,omitempty"`
+}
+
+// MarketLine uniquely identifies one line of the market.
+type MarketLine struct {
+	MarketID int `json:"marketID"`
+	LineID   int `json:"lineID"`
 }
 
 func (t *BetStop) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
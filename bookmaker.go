@@ -0,0 +1,25 @@
+package uof
+
+import "time"
+
+// BookmakerDetails is response of the whoami api call. Describes bookmaker
+// for which the access token is issued.
+// Reference: https://docs.betradar.com/display/BD/UOF+-+Access+token+validation
+type BookmakerDetails struct {
+	BookmakerID int       `xml:"bookmaker_id,attr" json:"bookmakerID"`
+	VirtualHost string    `xml:"virtual_host,attr,omitempty" json:"virtualHost,omitempty"`
+	ExpireAt    time.Time `xml:"expire_at,attr,omitempty" json:"expireAt,omitempty"`
+	// Difference between api server clock and the local clock, positive if
+	// server clock is ahead. Calculated from the Date header of the response.
+	ServerTimeOffset time.Duration `xml:"-" json:"serverTimeOffset,omitempty"`
+	ResponseCode     string        `xml:"response_code,attr,omitempty" json:"responseCode,omitempty"`
+	Message          string        `xml:"message,omitempty" json:"message,omitempty"`
+}
+
+// ExpiresWithin reports whether the access token expires in less than d.
+func (b *BookmakerDetails) ExpiresWithin(d time.Duration) bool {
+	if b.ExpireAt.IsZero() {
+		return false
+	}
+	return time.Until(b.ExpireAt) < d
+}
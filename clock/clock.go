@@ -0,0 +1,174 @@
+// Package clock follows live match clock of the events from the odds change
+// messages, and extrapolates it between the messages so that it can be shown
+// as ticking clock.
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/minus5/go-uof-sdk"
+)
+
+const (
+	// events without updates are removed after
+	eventTTL = 24 * time.Hour
+	// how often to look for expired events
+	sweepInterval = time.Hour
+)
+
+// Time of the match clock at some moment. Unset and invalid values are zero.
+type Time struct {
+	MatchTime             time.Duration `json:"matchTime"`
+	StoppageTime          time.Duration `json:"stoppageTime,omitempty"`
+	StoppageTimeAnnounced time.Duration `json:"stoppageTimeAnnounced,omitempty"`
+	RemainingTime         time.Duration `json:"remainingTime,omitempty"`
+	RemainingTimeInPeriod time.Duration `json:"remainingTimeInPeriod,omitempty"`
+	Stopped               bool          `json:"stopped,omitempty"`
+}
+
+// state of the event clock in the last odds change
+type state struct {
+	Time
+	// which values are set in the clock, only those are extrapolated
+	has has
+	// odds change timestamp
+	at time.Time
+}
+
+type has struct {
+	matchTime             bool
+	stoppageTime          bool
+	remainingTime         bool
+	remainingTimeInPeriod bool
+}
+
+// Tracker keeps last clock state for each event.
+type Tracker struct {
+	events  map[int]*state
+	sweptAt time.Time
+	sync.RWMutex
+}
+
+func New() *Tracker {
+	return &Tracker{
+		events: make(map[int]*state),
+	}
+}
+
+// Put updates event clock from the odds change message. Other messages are
+// ignored, as well as odds changes older than the last one.
+func (t *Tracker) Put(m *uof.Message) {
+	if m == nil || m.Type != uof.MessageTypeOddsChange || m.OddsChange == nil {
+		return
+	}
+	oc := m.OddsChange
+	es := oc.EventStatus
+	if es == nil {
+		return
+	}
+	at := time.Unix(0, int64(oc.Timestamp)*int64(time.Millisecond))
+
+	t.Lock()
+	defer t.Unlock()
+	t.sweep(at)
+	if es.Status == uof.EventStatusClosed {
+		delete(t.events, oc.EventID)
+		return
+	}
+	s, ok := t.events[oc.EventID]
+	if ok && at.Before(s.at) {
+		return
+	}
+	n := &state{at: at}
+	if c := es.Clock; c != nil {
+		n.MatchTime, n.has.matchTime = duration(c.MatchTime)
+		n.StoppageTime, n.has.stoppageTime = duration(c.StoppageTime)
+		n.StoppageTimeAnnounced, _ = duration(c.StoppageTimeAnnounced)
+		n.RemainingTime, n.has.remainingTime = duration(c.RemainingTime)
+		n.RemainingTimeInPeriod, n.has.remainingTimeInPeriod = duration(c.RemainingTimeInPeriod)
+		if c.Stopped != nil {
+			n.Stopped = *c.Stopped
+		}
+	} else if ok {
+		// keep last clock, status without clock still moves it forward
+		n.Time = s.extrapolate(at)
+		n.has = s.has
+	} else {
+		return
+	}
+	if es.Status != uof.EventStatusLive {
+		n.Stopped = true
+	}
+	t.events[oc.EventID] = n
+}
+
+// Get returns event clock extrapolated to the now.
+func (t *Tracker) Get(eventID int, now time.Time) (Time, bool) {
+	t.RLock()
+	defer t.RUnlock()
+	s, ok := t.events[eventID]
+	if !ok {
+		return Time{}, false
+	}
+	return s.extrapolate(now), true
+}
+
+// Delete removes event clock.
+func (t *Tracker) Delete(eventID int) {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.events, eventID)
+}
+
+// extrapolate moves running clock for the time passed from the odds change.
+// Match time goes forward, or stoppage time during the stoppage; remaining
+// times go down to zero. Values which are not set are not moved.
+func (s *state) extrapolate(now time.Time) Time {
+	c := s.Time
+	d := now.Sub(s.at)
+	if c.Stopped || d <= 0 {
+		return c
+	}
+	switch {
+	case s.has.stoppageTime:
+		c.StoppageTime += d
+	case s.has.matchTime:
+		c.MatchTime += d
+	}
+	if s.has.remainingTime {
+		c.RemainingTime = countdown(c.RemainingTime, d)
+	}
+	if s.has.remainingTimeInPeriod {
+		c.RemainingTimeInPeriod = countdown(c.RemainingTimeInPeriod, d)
+	}
+	return c
+}
+
+func countdown(r, d time.Duration) time.Duration {
+	if r < d {
+		return 0
+	}
+	return r - d
+}
+
+func (t *Tracker) sweep(now time.Time) {
+	if now.Sub(t.sweptAt) < sweepInterval {
+		return
+	}
+	t.sweptAt = now
+	for id, s := range t.events {
+		if now.Sub(s.at) > eventTTL {
+			delete(t.events, id)
+		}
+	}
+}
+
+// duration of the clock time, false if it is not set or invalid
+func duration(c *uof.ClockTime) (time.Duration, bool) {
+	d, err := c.Duration()
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
@@ -0,0 +1,118 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minus5/go-uof-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func oddsChange(eventID int, ts time.Time, status uof.EventStatus, c *uof.Clock) *uof.Message {
+	return &uof.Message{
+		Header: uof.Header{Type: uof.MessageTypeOddsChange, EventID: eventID},
+		Body: uof.Body{OddsChange: &uof.OddsChange{
+			EventID:     eventID,
+			Timestamp:   int(ts.UnixNano() / int64(time.Millisecond)),
+			EventStatus: &uof.SportEventStatus{Status: status, Clock: c},
+		}},
+	}
+}
+
+func clockTime(s string) *uof.ClockTime {
+	c := uof.ClockTime(s)
+	return &c
+}
+
+func TestTracker(t *testing.T) {
+	tr := New()
+	t0 := time.Now().Truncate(time.Millisecond)
+
+	_, ok := tr.Get(1, t0)
+	assert.False(t, ok)
+
+	tr.Put(oddsChange(1, t0, uof.EventStatusLive, &uof.Clock{
+		MatchTime:     clockTime("42:10"),
+		RemainingTime: clockTime("47:50"),
+	}))
+	c, ok := tr.Get(1, t0.Add(15*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 42*time.Minute+25*time.Second, c.MatchTime)
+	assert.Equal(t, 47*time.Minute+35*time.Second, c.RemainingTime)
+	assert.Equal(t, time.Duration(0), c.StoppageTime)
+
+	// older message is ignored
+	tr.Put(oddsChange(1, t0.Add(-time.Second), uof.EventStatusLive, &uof.Clock{MatchTime: clockTime("10:00")}))
+	c, _ = tr.Get(1, t0)
+	assert.Equal(t, 42*time.Minute+10*time.Second, c.MatchTime)
+
+	// stopped clock doesn't move
+	stopped := true
+	tr.Put(oddsChange(1, t0.Add(time.Minute), uof.EventStatusLive, &uof.Clock{MatchTime: clockTime("43:00"), Stopped: &stopped}))
+	c, _ = tr.Get(1, t0.Add(time.Hour))
+	assert.Equal(t, 43*time.Minute, c.MatchTime)
+	assert.True(t, c.Stopped)
+
+	// stoppage time
+	t1 := t0.Add(3 * time.Minute)
+	tr.Put(oddsChange(1, t1, uof.EventStatusLive, &uof.Clock{
+		MatchTime:             clockTime("45:00"),
+		StoppageTime:          clockTime("0:30"),
+		StoppageTimeAnnounced: clockTime("2"),
+	}))
+	c, _ = tr.Get(1, t1.Add(10*time.Second))
+	// match time stands during the stoppage
+	assert.Equal(t, 45*time.Minute, c.MatchTime)
+	assert.Equal(t, 40*time.Second, c.StoppageTime)
+	assert.Equal(t, 2*time.Minute, c.StoppageTimeAnnounced)
+
+	// odds change without clock keeps extrapolated clock
+	tr.Put(oddsChange(1, t1.Add(20*time.Second), uof.EventStatusLive, nil))
+	c, _ = tr.Get(1, t1.Add(30*time.Second))
+	assert.Equal(t, 45*time.Minute, c.MatchTime)
+	assert.Equal(t, time.Minute, c.StoppageTime)
+
+	// ended match is stopped
+	tr.Put(oddsChange(1, t1.Add(time.Minute), uof.EventStatusEnded, &uof.Clock{MatchTime: clockTime("90:00")}))
+	c, _ = tr.Get(1, t1.Add(time.Hour))
+	assert.Equal(t, 90*time.Minute, c.MatchTime)
+	assert.True(t, c.Stopped)
+
+	// closed is removed
+	tr.Put(oddsChange(1, t1.Add(2*time.Minute), uof.EventStatusClosed, nil))
+	_, ok = tr.Get(1, t1)
+	assert.False(t, ok)
+}
+
+func TestTrackerIgnored(t *testing.T) {
+	tr := New()
+	t0 := time.Now().Truncate(time.Millisecond)
+	tr.Put(nil)
+	tr.Put(&uof.Message{Header: uof.Header{Type: uof.MessageTypeBetStop}})
+	// without clock for unknown event
+	tr.Put(oddsChange(1, t0, uof.EventStatusLive, nil))
+	_, ok := tr.Get(1, t0)
+	assert.False(t, ok)
+
+	// invalid clock time is not set, and not extrapolated
+	tr.Put(oddsChange(2, t0, uof.EventStatusLive, &uof.Clock{MatchTime: clockTime("pero"), RemainingTimeInPeriod: clockTime("0:05")}))
+	c, ok := tr.Get(2, t0.Add(10*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), c.MatchTime)
+	assert.Equal(t, time.Duration(0), c.RemainingTimeInPeriod)
+
+	tr.Delete(2)
+	_, ok = tr.Get(2, t0)
+	assert.False(t, ok)
+}
+
+func TestTrackerSweep(t *testing.T) {
+	tr := New()
+	t0 := time.Now().Truncate(time.Millisecond)
+	tr.Put(oddsChange(1, t0, uof.EventStatusLive, &uof.Clock{MatchTime: clockTime("1:00")}))
+	tr.Put(oddsChange(2, t0.Add(eventTTL+sweepInterval), uof.EventStatusLive, &uof.Clock{MatchTime: clockTime("1:00")}))
+	_, ok := tr.Get(1, t0)
+	assert.False(t, ok)
+	_, ok = tr.Get(2, t0)
+	assert.True(t, ok)
+}
@@ -0,0 +1,90 @@
+// Command marketgroups rebuilds embedded market groups (pipe/market_groups.go)
+// from the market descriptions api. Groups of each market id are the same in
+// all languages.
+//
+// Run from the pipe directory with api token in UOF_TOKEN env:
+//
+//	go generate
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/minus5/go-uof-sdk"
+	"github.com/minus5/go-uof-sdk/api"
+)
+
+const EnvToken = "UOF_TOKEN"
+
+func main() {
+	output := flag.String("o", "market_groups.go", "output file")
+	production := flag.Bool("production", false, "use production api instead of staging")
+	flag.Parse()
+
+	token, ok := os.LookupEnv(EnvToken)
+	if !ok {
+		log.Fatalf("env %s not found", EnvToken)
+	}
+	env := uof.Staging
+	if *production {
+		env = uof.Production
+	}
+	a, err := api.Dial(context.Background(), env, token)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ms, err := a.Markets(uof.LangEN)
+	if err != nil {
+		log.Fatal(err)
+	}
+	buf, err := generate(ms)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*output, buf, 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("%d markets written to %s", len(ms), *output)
+}
+
+func generate(ms uof.MarketDescriptions) ([]byte, error) {
+	groups := make(map[int][]string)
+	for _, md := range ms {
+		if len(md.Groups) == 0 {
+			continue
+		}
+		groups[md.ID] = merge(groups[md.ID], md.Groups)
+	}
+	data, err := json.Marshal(groups)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString("// Code generated by cmd/marketgroups; DO NOT EDIT.\n\n")
+	b.WriteString("package pipe\n\n")
+	b.WriteString("// groups of each market id\n")
+	b.WriteString("var marketGroupsJSON = `" + string(data) + "`\n")
+	return format.Source(b.Bytes())
+}
+
+// merge groups of the market variants into sorted list
+func merge(a, b []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, g := range append(a, b...) {
+		if !seen[g] {
+			seen[g] = true
+			out = append(out, g)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
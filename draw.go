@@ -0,0 +1,136 @@
+package uof
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Numbers betting (WNS) lexicon. Lottery is a game with regular draws, draw is
+// sport event for which odds changes and bet settlements are sent.
+// Reference: https://docs.betradar.com/display/BD/UOF+-+Numbers+Betting
+
+// DrawRsp draw fixture or summary api response. Draw result is set only from
+// the summary.
+type DrawRsp struct {
+	Draw        Draw      `xml:"draw_fixture" json:"draw"`
+	GeneratedAt time.Time `xml:"generated_at,attr,omitempty" json:"generatedAt,omitempty"`
+}
+
+// Draw of the lottery.
+type Draw struct {
+	ID        int        `xml:"-" json:"id"`
+	URN       URN        `xml:"id,attr" json:"urn"`
+	DisplayID int        `xml:"display_id,attr,omitempty" json:"displayID,omitempty"`
+	DrawDate  time.Time  `xml:"draw_date,attr,omitempty" json:"drawDate,omitempty"`
+	Status    DrawStatus `xml:"status,attr,omitempty" json:"status,omitempty"`
+	Lottery   Lottery    `xml:"lottery" json:"lottery"`
+	// drawn numbers in the draw order, set when draw is finished
+	Result []int `xml:"-" json:"result,omitempty"`
+}
+
+// DrawStatus status of the lottery draw.
+type DrawStatus string
+
+const (
+	DrawStatusOpen     DrawStatus = "open"
+	DrawStatusClosed   DrawStatus = "closed"
+	DrawStatusFinished DrawStatus = "finished"
+	DrawStatusCanceled DrawStatus = "canceled"
+)
+
+// Lottery description with the draws schedule.
+type Lottery struct {
+	ID        int        `json:"id"`
+	URN       URN        `xml:"id,attr" json:"urn"`
+	Name      string     `xml:"name,attr" json:"name"`
+	Sport     Sport      `xml:"sport" json:"sport"`
+	Category  Category   `xml:"category" json:"category"`
+	BonusInfo *BonusInfo `xml:"bonus_info,omitempty" json:"bonusInfo,omitempty"`
+	DrawInfo  *DrawInfo  `xml:"draw_info,omitempty" json:"drawInfo,omitempty"`
+	// upcoming draws, set in the lottery schedule
+	Draws []DrawEvent `xml:"-" json:"draws,omitempty"`
+}
+
+type BonusInfo struct {
+	BonusBalls     int    `xml:"bonus_balls,attr,omitempty" json:"bonusBalls,omitempty"`
+	BonusBallRange string `xml:"bonus_ball_range,attr,omitempty" json:"bonusBallRange,omitempty"`
+}
+
+type DrawInfo struct {
+	DrawType string `xml:"draw_type,attr,omitempty" json:"drawType,omitempty"`
+	TimeType string `xml:"time_type,attr,omitempty" json:"timeType,omitempty"`
+	GameType string `xml:"game_type,attr,omitempty" json:"gameType,omitempty"`
+}
+
+// DrawEvent draw in the lottery schedule.
+type DrawEvent struct {
+	ID        int        `xml:"-" json:"id"`
+	URN       URN        `xml:"id,attr" json:"urn"`
+	DisplayID int        `xml:"display_id,attr,omitempty" json:"displayID,omitempty"`
+	DrawDate  time.Time  `xml:"scheduled,attr,omitempty" json:"drawDate,omitempty"`
+	Status    DrawStatus `xml:"status,attr,omitempty" json:"status,omitempty"`
+}
+
+// LotteryScheduleRsp lottery schedule api response.
+type LotteryScheduleRsp struct {
+	Lottery     Lottery     `xml:"lottery" json:"lottery"`
+	Draws       []DrawEvent `xml:"draw_events>draw_event" json:"draws"`
+	GeneratedAt time.Time   `xml:"generated_at,attr,omitempty" json:"generatedAt,omitempty"`
+}
+
+func (t *DrawRsp) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type T DrawRsp
+	var overlay struct {
+		*T
+		Result []struct {
+			Value int `xml:"value,attr"`
+		} `xml:"draw_result>draws>draw"`
+	}
+	overlay.T = (*T)(t)
+	if err := d.DecodeElement(&overlay, &start); err != nil {
+		return err
+	}
+	for _, r := range overlay.Result {
+		t.Draw.Result = append(t.Draw.Result, r.Value)
+	}
+	return nil
+}
+
+func (t *Draw) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type T Draw
+	var overlay struct {
+		*T
+	}
+	overlay.T = (*T)(t)
+	if err := d.DecodeElement(&overlay, &start); err != nil {
+		return err
+	}
+	t.ID = t.URN.EventID()
+	return nil
+}
+
+func (t *DrawEvent) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type T DrawEvent
+	var overlay struct {
+		*T
+	}
+	overlay.T = (*T)(t)
+	if err := d.DecodeElement(&overlay, &start); err != nil {
+		return err
+	}
+	t.ID = t.URN.EventID()
+	return nil
+}
+
+func (t *Lottery) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type T Lottery
+	var overlay struct {
+		*T
+	}
+	overlay.T = (*T)(t)
+	if err := d.DecodeElement(&overlay, &start); err != nil {
+		return err
+	}
+	t.ID = t.URN.ID()
+	return nil
+}
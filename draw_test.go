@@ -0,0 +1,67 @@
+package uof
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrawSummary(t *testing.T) {
+	buf, err := ioutil.ReadFile("./testdata/draw-0.xml")
+	assert.NoError(t, err)
+
+	m, err := NewDrawMessageFromBuf(LangEN, buf, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, MessageTypeDraw, m.Type)
+	assert.Equal(t, MessageKindLexicon, m.Type.Kind())
+	assert.Equal(t, URN("wns:draw:1221541870"), m.EventURN)
+	assert.Equal(t, m.EventURN.EventID(), m.EventID)
+
+	d := m.Draw
+	assert.Equal(t, m.EventID, d.ID)
+	assert.Equal(t, 1221, d.DisplayID)
+	assert.Equal(t, "2020-04-02T18:00:00Z", d.DrawDate.Format(time.RFC3339))
+	assert.Equal(t, DrawStatusFinished, d.Status)
+	assert.Equal(t, []int{12, 3, 41, 27, 8, 33}, d.Result)
+
+	l := d.Lottery
+	assert.Equal(t, 1, l.ID)
+	assert.Equal(t, "Lotto 6 aus 49", l.Name)
+	assert.Equal(t, 108, l.Sport.ID)
+	assert.Equal(t, "Germany", l.Category.Name)
+	assert.Equal(t, BonusInfo{BonusBalls: 1, BonusBallRange: "0-9"}, *l.BonusInfo)
+	assert.Equal(t, DrawInfo{DrawType: "drum", TimeType: "fixed", GameType: "6/49"}, *l.DrawInfo)
+
+	// survives json round trip
+	m2 := &Message{}
+	assert.NoError(t, m2.Unmarshal(m.Marshal()))
+	assert.Equal(t, *d, *m2.Draw)
+}
+
+func TestDrawFixture(t *testing.T) {
+	buf, err := ioutil.ReadFile("./testdata/draw-1.xml")
+	assert.NoError(t, err)
+
+	m, err := NewDrawMessageFromBuf(LangEN, buf, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, DrawStatusOpen, m.Draw.Status)
+	assert.Nil(t, m.Draw.Result)
+	assert.Equal(t, "Lotto 6 aus 49", m.Draw.Lottery.Name)
+}
+
+func TestLotterySchedule(t *testing.T) {
+	buf, err := ioutil.ReadFile("./testdata/lottery-0.xml")
+	assert.NoError(t, err)
+
+	var rsp LotteryScheduleRsp
+	assert.NoError(t, xml.Unmarshal(buf, &rsp))
+	assert.Equal(t, "Lotto 6 aus 49", rsp.Lottery.Name)
+	assert.Len(t, rsp.Draws, 2)
+	assert.Equal(t, URN("wns:draw:1221541871"), rsp.Draws[1].URN)
+	assert.Equal(t, rsp.Draws[1].URN.EventID(), rsp.Draws[1].ID)
+	assert.Equal(t, 1222, rsp.Draws[1].DisplayID)
+	assert.Equal(t, "2020-04-04T18:00:00Z", rsp.Draws[1].DrawDate.Format(time.RFC3339))
+}
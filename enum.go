@@ -5,6 +5,7 @@ import (
 	"hash/fnv"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Producer int8
@@ -85,11 +86,15 @@ func (p Producer) Prematch() bool {
 }
 
 const (
-	InvalidName = "?"
-	srMatch     = "sr:match:"
-	srPlayer    = "sr:player:"
+	InvalidName  = "?"
+	srMatch      = "sr:match:"
+	srPlayer     = "sr:player:"
+	srCompetitor = "sr:competitor:"
 )
 
+// URN is in the prefix:type:id format. Prefix is the source of the id (sr for
+// Sportradar, vf, wns..., or bookmaker's custom prefix), type is entity type
+// (match, stage, competitor...).
 type URN string
 
 func (u URN) ID() int {
@@ -175,61 +180,26 @@ const NoURN = URN("")
 //            http://sdk.sportradar.com/content/unifiedfeedsdk/net/doc/html/e1f73019-73cd-c9f8-0d58-7fe25800abf2.htm
 // List of currently existing event types is taken from the combo box in the
 // integration control page. From method "Fixture for a specified sport event".
-//nolint:gocyclo //accepting complexity of 23
+// Unregistered types got suffix from the reserved range by hash of the type
+// (see urnTypes), use FromEventID to get urn of the registered types back from
+// the id.
 func (u URN) EventID() int {
 	id, prefix := u.split()
 	if id == 0 {
 		return 0
 	}
 
-	suffixID := func(suffix int8) int {
-		return -(id<<8 | int(suffix))
-	}
-
-	switch prefix {
-	case "sr:match":
+	if prefix == "sr:match" {
 		return id
-	case "sr:stage":
-		return suffixID(1)
-	case "sr:season":
-		return suffixID(2)
-	case "sr:tournament":
-		return suffixID(3)
-	case "sr:simple_tournament":
-		return suffixID(4)
-	case "test:match":
-		return suffixID(15)
-	case "vf:match":
-		return suffixID(16)
-	case "vf:season":
-		return suffixID(17)
-	case "vf:tournament":
-		return suffixID(18)
-	case "vbl:match":
-		return suffixID(19)
-	case "vbl:season":
-		return suffixID(20)
-	case "vbl:tournament":
-		return suffixID(21)
-	case "vto:match":
-		return suffixID(22)
-	case "vto:season":
-		return suffixID(23)
-	case "vto:tournament":
-		return suffixID(24)
-	case "vdr:stage":
-		return suffixID(25)
-	case "vhc:stage":
-		return suffixID(26)
-	case "vti:match":
-		return suffixID(27)
-	case "vti:tournament":
-		return suffixID(28)
-	case "wns:draw":
-		return suffixID(29)
-	default:
-		return 0
 	}
+	return -(id<<8 | urnTypes.suffix(prefix))
+}
+
+// IsStage reports whether urn is one of the stage types (sr:stage, vdr:stage,
+// vhc:stage).
+func (u URN) IsStage() bool {
+	_, prefix := u.split()
+	return strings.HasSuffix(prefix, ":stage")
 }
 
 // splits urn into id and prefix
@@ -261,7 +231,9 @@ func toLineID(specifiers string) int {
 func hash32(s string) int {
 	h := fnv.New32a()
 	_, _ = h.Write([]byte(s))
-	return int(h.Sum32())
+	v := int(h.Sum32())
+	hashes.put(v, s)
+	return v
 }
 
 func Hash(s string) int {
@@ -287,6 +259,33 @@ func (c *ClockTime) Minute() string {
 	return ""
 }
 
+// Duration converts mm:ss or mm clock time to duration.
+func (c *ClockTime) Duration() (time.Duration, error) {
+	if c == nil || *c == "" {
+		return 0, fmt.Errorf("empty clock time")
+	}
+	p := strings.Split(string(*c), ":")
+	if len(p) > 2 {
+		return 0, fmt.Errorf("invalid clock time %s", *c)
+	}
+	var d time.Duration
+	for i, x := range p {
+		if x == "" || strings.TrimLeft(x, "0123456789") != "" {
+			return 0, fmt.Errorf("invalid clock time %s", *c)
+		}
+		n, err := strconv.Atoi(x)
+		if err != nil {
+			return 0, fmt.Errorf("invalid clock time %s", *c)
+		}
+		if i == 0 {
+			d += time.Duration(n) * time.Minute
+		} else {
+			d += time.Duration(n) * time.Second
+		}
+	}
+	return d, nil
+}
+
 func (c *ClockTime) String() string {
 	return string(*c)
 }
@@ -344,6 +343,7 @@ const (
 	MessageTypeFixture MessageType = iota + 32
 	MessageTypeMarkets
 	MessageTypePlayer
+	MessageTypeDraw
 )
 
 // system message types
@@ -368,6 +368,7 @@ var messageTypes = []MessageType{
 	MessageTypeFixture,
 	MessageTypeMarkets,
 	MessageTypePlayer,
+	MessageTypeDraw,
 
 	MessageTypeAlive,
 	MessageTypeSnapshotComplete,
@@ -389,6 +390,7 @@ var messageTypeNames = []string{
 	"fixture",
 	"market",
 	"player",
+	"draw",
 
 	"alive",
 	"snapshot_complete",
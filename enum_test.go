@@ -1,7 +1,10 @@
 package uof
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -80,7 +83,7 @@ func TestURNEventID(t *testing.T) {
 	assert.Equal(t, -0xff01, URN("sr:stage:255").EventID())
 	assert.Equal(t, -0xff02, URN("sr:season:255").EventID())
 	assert.Equal(t, -0xff1C, URN("vti:tournament:255").EventID())
-	assert.Equal(t, 0, URN("pero:zdero:255").EventID())
+	assert.Equal(t, 0, URN("pero:zdero").EventID())
 
 	data := []struct {
 		u  string
@@ -114,8 +117,15 @@ func TestURNEventID(t *testing.T) {
 		{"vti:tournament:255", -0xff1C},
 
 		{"wns:draw:255", -0xff1D},
+		{"wns:lottery:255", -0xff1E},
+
+		{"sr:competitor:255", -0xff05},
+		{"sr:venue:255", -0xff06},
+		{"sr:player:255", -0xff07},
+		{"sr:category:255", -0xff08},
+		{"sr:sport:255", -0xff09},
 		// invalid
-		{"pero:zdero:255", 0},
+		{"sr:match:pero", 0},
 	}
 	for _, d := range data {
 		assert.Equal(t, d.id, URN(d.u).EventID())
@@ -137,3 +147,62 @@ func TestProducersChange(t *testing.T) {
 	pc.Add(ProducerPrematch, 456)
 	assert.Len(t, pc, 2)
 }
+
+func TestOddsChangeEnums(t *testing.T) {
+	assert.Equal(t, "possible_goal", BettingStatusPossibleGoal.String())
+	assert.Equal(t, "5", BettingStatus(5).String())
+	assert.Equal(t, InvalidName, BettingStatus(7).String())
+	assert.Equal(t, "possible_red_card", BetstopReasonPossibleRedCard.String())
+	assert.Equal(t, "87", BetstopReason(87).String())
+	assert.Equal(t, InvalidName, BetstopReason(88).String())
+	assert.Equal(t, "risk_adjustment", OddsChangeReasonRiskAdjustment.String())
+	assert.Equal(t, InvalidName, OddsChangeReason(2).String())
+
+	bs, br, ocr := BettingStatusPossiblePenalty, BetstopReason(42), OddsChangeReasonRiskAdjustment
+	oc := OddsChange{BettingStatus: &bs, BetstopReason: &br, OddsChangeReason: &ocr}
+	buf, err := json.Marshal(oc)
+	assert.NoError(t, err)
+	assert.Contains(t, string(buf), `"bettingStatus":"possible_penalty","betstopReason":42,"oddsChangeReason":"risk_adjustment"`)
+	var oc2 OddsChange
+	assert.NoError(t, json.Unmarshal(buf, &oc2))
+	assert.Equal(t, oc, oc2)
+	assert.True(t, oc2.IsRiskAdjustment())
+	assert.NoError(t, oc2.Validate())
+
+	// numbers are also accepted
+	assert.NoError(t, json.Unmarshal([]byte(`{"bettingStatus":1}`), &oc2))
+	assert.Equal(t, BettingStatusPossibleGoal, *oc2.BettingStatus)
+	assert.Error(t, json.Unmarshal([]byte(`{"bettingStatus":"pero"}`), &oc2))
+
+	br = BetstopReason(100)
+	err = oc.Validate()
+	assert.Error(t, err)
+	var e Error
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, NoticeSeverity, e.Severity)
+}
+
+func TestClockTimeDuration(t *testing.T) {
+	data := []struct {
+		c ClockTime
+		d time.Duration
+	}{
+		{"0", 0},
+		{"42", 42 * time.Minute},
+		{"42:10", 42*time.Minute + 10*time.Second},
+		{"90:00", 90 * time.Minute},
+		{"120:59", 120*time.Minute + 59*time.Second},
+	}
+	for _, d := range data {
+		v, err := d.c.Duration()
+		assert.NoError(t, err, d.c)
+		assert.Equal(t, d.d, v, d.c)
+	}
+	for _, c := range []ClockTime{"", ":", "42:", ":10", "1:2:3", "-1", "4a:10", "+5", "42.5"} {
+		_, err := c.Duration()
+		assert.Error(t, err, c)
+	}
+	var c *ClockTime
+	_, err := c.Duration()
+	assert.Error(t, err)
+}
@@ -1,5 +1,11 @@
 package uof
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
 // The default value is active if status is not present.
 type MarketStatus int8
 
@@ -49,11 +55,7 @@ func (m MarketStatus) Val() int8 {
 }
 
 func (m *MarketStatus) PtrVal() *int8 {
-	if m == nil {
-		return nil
-	}
-	v := int8(*m)
-	return &v
+	return int8Ptr((*int8)(m))
 }
 
 type CashoutStatus int8
@@ -68,11 +70,7 @@ const (
 )
 
 func (s *CashoutStatus) PtrVal() *int8 {
-	if s == nil {
-		return nil
-	}
-	v := int8(*s)
-	return &v
+	return int8Ptr((*int8)(s))
 }
 
 type Team int8
@@ -83,11 +81,7 @@ const (
 )
 
 func (t *Team) PtrVal() *int8 {
-	if t == nil {
-		return nil
-	}
-	v := int8(*t)
-	return &v
+	return int8Ptr((*int8)(t))
 }
 
 type EventStatus int8
@@ -113,11 +107,7 @@ func (s EventStatus) Val() int8 {
 }
 
 func (s *EventStatus) PtrVal() *int8 {
-	if s == nil {
-		return nil
-	}
-	v := int8(*s)
-	return &v
+	return int8Ptr((*int8)(s))
 }
 
 type OutcomeResult int8
@@ -160,3 +150,192 @@ const (
 	Male
 	Female
 )
+
+// BettingStatus in the odds change message, reason why betting is stopped
+// for the event.
+// Values are in range 0-6, only most common have names, full list with
+// descriptions is at /v1/descriptions/betting_status.xml.
+type BettingStatus int8
+
+const (
+	BettingStatusUnknown         BettingStatus = 0
+	BettingStatusPossibleGoal    BettingStatus = 1
+	BettingStatusPossibleRedCard BettingStatus = 2
+	BettingStatusPossiblePenalty BettingStatus = 3
+
+	maxBettingStatus BettingStatus = 6
+)
+
+var bettingStatusNames = enumNames{
+	int(BettingStatusUnknown):         "unknown",
+	int(BettingStatusPossibleGoal):    "possible_goal",
+	int(BettingStatusPossibleRedCard): "possible_red_card",
+	int(BettingStatusPossiblePenalty): "possible_penalty",
+}
+
+func (s BettingStatus) Val() int8 {
+	return int8(s)
+}
+
+func (s *BettingStatus) PtrVal() *int8 {
+	return int8Ptr((*int8)(s))
+}
+
+// Valid reports whether value is in the range of documented values.
+func (s BettingStatus) Valid() bool {
+	return s >= 0 && s <= maxBettingStatus
+}
+
+func (s BettingStatus) String() string {
+	return enumString(int(s), s.Valid(), bettingStatusNames[int(s)])
+}
+
+func (s BettingStatus) MarshalJSON() ([]byte, error) {
+	return enumMarshalJSON(int(s), bettingStatusNames[int(s)])
+}
+
+func (s *BettingStatus) UnmarshalJSON(buf []byte) error {
+	v, err := bettingStatusNames.unmarshalJSON(buf)
+	*s = BettingStatus(v)
+	return err
+}
+
+// BetstopReason in the odds change message, reason for the markets
+// suspension.
+// Only most common values have names, full list with descriptions is at
+// /v1/descriptions/betstop_reasons.xml.
+type BetstopReason int8
+
+const (
+	BetstopReasonUnknown         BetstopReason = 0
+	BetstopReasonPossibleGoal    BetstopReason = 1
+	BetstopReasonPossibleRedCard BetstopReason = 2
+	BetstopReasonPossiblePenalty BetstopReason = 3
+
+	maxBetstopReason BetstopReason = 87
+)
+
+var betstopReasonNames = enumNames{
+	int(BetstopReasonUnknown):         "unknown",
+	int(BetstopReasonPossibleGoal):    "possible_goal",
+	int(BetstopReasonPossibleRedCard): "possible_red_card",
+	int(BetstopReasonPossiblePenalty): "possible_penalty",
+}
+
+func (r BetstopReason) Val() int8 {
+	return int8(r)
+}
+
+func (r *BetstopReason) PtrVal() *int8 {
+	return int8Ptr((*int8)(r))
+}
+
+// Valid reports whether value is in the range of documented values.
+func (r BetstopReason) Valid() bool {
+	return r >= 0 && r <= maxBetstopReason
+}
+
+func (r BetstopReason) String() string {
+	return enumString(int(r), r.Valid(), betstopReasonNames[int(r)])
+}
+
+func (r BetstopReason) MarshalJSON() ([]byte, error) {
+	return enumMarshalJSON(int(r), betstopReasonNames[int(r)])
+}
+
+func (r *BetstopReason) UnmarshalJSON(buf []byte) error {
+	v, err := betstopReasonNames.unmarshalJSON(buf)
+	*r = BetstopReason(v)
+	return err
+}
+
+// OddsChangeReason in the odds change message. Not set for the regular odds
+// changes.
+type OddsChangeReason int8
+
+const (
+	// odds are changed because of the bookmaker's risk management
+	OddsChangeReasonRiskAdjustment OddsChangeReason = 1
+)
+
+var oddsChangeReasonNames = enumNames{
+	int(OddsChangeReasonRiskAdjustment): "risk_adjustment",
+}
+
+func (r OddsChangeReason) Val() int8 {
+	return int8(r)
+}
+
+func (r *OddsChangeReason) PtrVal() *int8 {
+	return int8Ptr((*int8)(r))
+}
+
+// Valid reports whether value is one of the named values.
+func (r OddsChangeReason) Valid() bool {
+	_, ok := oddsChangeReasonNames[int(r)]
+	return ok
+}
+
+func (r OddsChangeReason) String() string {
+	return enumString(int(r), r.Valid(), oddsChangeReasonNames[int(r)])
+}
+
+func (r OddsChangeReason) MarshalJSON() ([]byte, error) {
+	return enumMarshalJSON(int(r), oddsChangeReasonNames[int(r)])
+}
+
+func (r *OddsChangeReason) UnmarshalJSON(buf []byte) error {
+	v, err := oddsChangeReasonNames.unmarshalJSON(buf)
+	*r = OddsChangeReason(v)
+	return err
+}
+
+// enumString returns name of the value, number for valid values without
+// name, and InvalidName for invalid.
+func enumString(v int, valid bool, name string) string {
+	if name != "" {
+		return name
+	}
+	if valid {
+		return strconv.Itoa(v)
+	}
+	return InvalidName
+}
+
+// enumMarshalJSON marshals value as name, or as number if there is no name.
+func enumMarshalJSON(v int, name string) ([]byte, error) {
+	if name != "" {
+		return json.Marshal(name)
+	}
+	return json.Marshal(v)
+}
+
+// enumNames names of the enum values
+type enumNames map[int]string
+
+// unmarshalJSON accepts both name and number.
+func (n enumNames) unmarshalJSON(buf []byte) (int, error) {
+	var name string
+	if err := json.Unmarshal(buf, &name); err == nil {
+		for v, vn := range n {
+			if vn == name {
+				return v, nil
+			}
+		}
+		return 0, fmt.Errorf("unknown enum name %s", name)
+	}
+	var v int
+	if err := json.Unmarshal(buf, &v); err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
+// int8Ptr copy of the enum value, nil for nil
+func int8Ptr(p *int8) *int8 {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
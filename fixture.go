@@ -45,14 +45,13 @@ type Fixture struct {
 
 	ExtraInfo []ExtraInfo  `xml:"extra_info>info,omitempty" json:"extraInfo,omitempty"`
 	Races     []SportEvent `xml:"races>sport_event,omitempty" json:"races,omitempty"`
-	// this also exists but we are skiping for the time being
-	//ReferenceIDs         ReferenceIDs         `xml:"reference_ids,omitempty" json:"referenceIDs,omitempty"`
-	//SportEventConditions SportEventConditions `xml:"sport_event_conditions,omitempty" json:"sportEventConditions,omitempty"`
-	//DelayedInfo DelayedInfo `xml:"delayed_info,omitempty" json:"delayedInfo,omitempty"`
-	//CoverageInfo CoverageInfo `xml:"coverage_info,omitempty" json:"coverageInfo,omitempty"`
-	//ScheduledStartTimeChanges []ScheduledStartTimeChange `xml:"scheduled_start_time_changes>scheduled_start_time_change,omitempty" json:"scheduledStartTimeChanges,omitempty"`
-	//Parent *ParentStage `xml:"parent,omitempty" json:"parent,omitempty"`
 
+	ReferenceIDs              []ReferenceID              `xml:"reference_ids>reference_id,omitempty" json:"referenceIDs,omitempty"`
+	SportEventConditions      *SportEventConditions      `xml:"sport_event_conditions,omitempty" json:"sportEventConditions,omitempty"`
+	DelayedInfo               *DelayedInfo               `xml:"delayed_info,omitempty" json:"delayedInfo,omitempty"`
+	CoverageInfo              *CoverageInfo              `xml:"coverage_info,omitempty" json:"coverageInfo,omitempty"`
+	ScheduledStartTimeChanges []ScheduledStartTimeChange `xml:"scheduled_start_time_changes>scheduled_start_time_change,omitempty" json:"scheduledStartTimeChanges,omitempty"`
+	Parent                    *ParentStage               `xml:"parent,omitempty" json:"parent,omitempty"`
 }
 
 type Tournament struct {
@@ -80,7 +79,9 @@ type Competitor struct {
 	CountryCode  string             `xml:"country_code,attr,omitempty" json:"countryCode,omitempty"`
 	Virtual      bool               `xml:"virtual,attr,omitempty" json:"virtual,omitempty"`
 	Players      []CompetitorPlayer `xml:"players>player,omitempty" json:"players,omitempty"`
-	//ReferenceIDs CompetitorReferenceIDs `xml:"reference_ids,omitempty" json:"referenceIDs,omitempty"`
+	ReferenceIDs []ReferenceID      `xml:"reference_ids>reference_id,omitempty" json:"referenceIDs,omitempty"`
+	// starting position of the race competitor, set when provided
+	DrawNumber int `xml:"draw_number,attr,omitempty" json:"drawNumber,omitempty"`
 }
 
 type CompetitorPlayer struct {
@@ -139,28 +140,74 @@ type Season struct {
 	//TournamentID string    `xml:"tournament_id,attr,omitempty" json:"tournamentID,omitempty"`
 }
 
-// type ParentStage struct {
-// 	URN          URN       `xml:"id,attr,omitempty" json:"urn,omitempty"`
-// 	Name         string    `xml:"name,attr,omitempty" json:"name,omitempty"`
-// 	Type         string    `xml:"type,attr,omitempty" json:"type,omitempty"`
-// 	Scheduled    time.Time `xml:"scheduled,attr,omitempty" json:"scheduled,omitempty"`
-// 	StartTimeTbd bool      `xml:"start_time_tbd,attr,omitempty" json:"startTimeTbd,omitempty"`
-// 	ScheduledEnd time.Time `xml:"scheduled_end,attr,omitempty" json:"scheduledEnd,omitempty"`
-// 	ReplacedBy   string    `xml:"replaced_by,attr,omitempty" json:"replacedBy,omitempty"`
-// }
+// ParentStage of the stage event, for example race in the season.
+type ParentStage struct {
+	ID           int       `json:"id"`
+	URN          URN       `xml:"id,attr,omitempty" json:"urn,omitempty"`
+	Name         string    `xml:"name,attr,omitempty" json:"name,omitempty"`
+	Type         string    `xml:"type,attr,omitempty" json:"type,omitempty"`
+	Scheduled    time.Time `xml:"scheduled,attr,omitempty" json:"scheduled,omitempty"`
+	StartTimeTbd bool      `xml:"start_time_tbd,attr,omitempty" json:"startTimeTbd,omitempty"`
+	ScheduledEnd time.Time `xml:"scheduled_end,attr,omitempty" json:"scheduledEnd,omitempty"`
+	ReplacedBy   string    `xml:"replaced_by,attr,omitempty" json:"replacedBy,omitempty"`
+}
+
+// ScheduledStartTimeChange one entry in the history of the start time changes.
+type ScheduledStartTimeChange struct {
+	OldTime   time.Time `xml:"old_time,attr" json:"oldTime"`
+	NewTime   time.Time `xml:"new_time,attr" json:"newTime"`
+	ChangedAt time.Time `xml:"changed_at,attr" json:"changedAt"`
+}
+
+// ReferenceID of the event or competitor in other Betradar systems.
+type ReferenceID struct {
+	Name  string `xml:"name,attr" json:"name"`
+	Value string `xml:"value,attr" json:"value"`
+}
+
+// SportEventConditions conditions under which event is played.
+type SportEventConditions struct {
+	Attendance  int          `xml:"attendance,attr,omitempty" json:"attendance,omitempty"`
+	MatchMode   string       `xml:"match_mode,attr,omitempty" json:"matchMode,omitempty"`
+	Referee     *Referee     `xml:"referee,omitempty" json:"referee,omitempty"`
+	WeatherInfo *WeatherInfo `xml:"weather_info,omitempty" json:"weatherInfo,omitempty"`
+}
 
-// type ScheduledStartTimeChange struct {
-// 	OldTime   time.Time `xml:"old_time,attr" json:"oldTime"`
-// 	NewTime   time.Time `xml:"new_time,attr" json:"newTime"`
-// 	ChangedAt time.Time `xml:"changed_at,attr" json:"changedAt"`
-// }
+type Referee struct {
+	ID          int    `json:"id"`
+	Name        string `xml:"name,attr" json:"name"`
+	Nationality string `xml:"nationality,attr,omitempty" json:"nationality,omitempty"`
+}
+
+// WeatherInfo weather and pitch conditions.
+type WeatherInfo struct {
+	Pitch              string `xml:"pitch,attr,omitempty" json:"pitch,omitempty"`
+	WeatherConditions  string `xml:"weather_conditions,attr,omitempty" json:"weatherConditions,omitempty"`
+	TemperatureCelsius *int   `xml:"temperature_celsius,attr,omitempty" json:"temperatureCelsius,omitempty"`
+	Wind               string `xml:"wind,attr,omitempty" json:"wind,omitempty"`
+	WindAdvantage      string `xml:"wind_advantage,attr,omitempty" json:"windAdvantage,omitempty"`
+}
+
+// DelayedInfo reason of the event delay.
+type DelayedInfo struct {
+	ID          int    `xml:"id,attr" json:"id"`
+	Description string `xml:"description,attr,omitempty" json:"description,omitempty"`
+}
+
+// CoverageInfo level of the live coverage and what is covered.
+type CoverageInfo struct {
+	Level        string   `xml:"level,attr,omitempty" json:"level,omitempty"`
+	LiveCoverage bool     `xml:"live_coverage,attr,omitempty" json:"liveCoverage,omitempty"`
+	CoveredFrom  string   `xml:"covered_from,attr,omitempty" json:"coveredFrom,omitempty"`
+	Includes     []string `xml:"-" json:"includes,omitempty"`
+}
 
 type ProductInfo struct {
 	Streaming            []StreamingChannel `xml:"streaming>channel,omitempty" json:"streaming,omitempty"`
-	IsInLiveScore        string             `xml:"is_in_live_score,omitempty" json:"isInLiveScore,omitempty"`
-	IsInHostedStatistics string             `xml:"is_in_hosted_statistics,omitempty" json:"isInHostedStatistics,omitempty"`
-	IsInLiveCenterSoccer string             `xml:"is_in_live_center_soccer,omitempty" json:"isInLiveCenterSoccer,omitempty"`
-	IsAutoTraded         string             `xml:"is_auto_traded,omitempty" json:"isAutoTraded,omitempty"`
+	IsInLiveScore        bool               `xml:"-" json:"isInLiveScore,omitempty"`
+	IsInHostedStatistics bool               `xml:"-" json:"isInHostedStatistics,omitempty"`
+	IsInLiveCenterSoccer bool               `xml:"-" json:"isInLiveCenterSoccer,omitempty"`
+	IsAutoTraded         bool               `xml:"-" json:"isAutoTraded,omitempty"`
 	Links                []ProductInfoLink  `xml:"links>link,omitempty" json:"links,omitempty"`
 }
 
@@ -174,11 +221,15 @@ type ExtraInfo struct {
 // SportEvent covers information about scheduled races in a stage
 // For VHC and VDR information is in vdr/vhc:stage:<int> fixture with type="parent"
 type SportEvent struct {
-	ID           string    `xml:"id,attr,omitempty" json:"id,omitempty"`
-	Name         string    `xml:"name,attr,omitempty" json:"name,omitempty"`
-	Type         string    `xml:"type,attr,omitempty" json:"type,omitempty"`
-	Scheduled    time.Time `xml:"scheduled,attr,omitempty" json:"scheduled,omitempty"`
-	ScheduledEnd time.Time `xml:"scheduled_end,attr,omitempty" json:"scheduled_end,omitempty"`
+	ID           int          `xml:"-" json:"id,omitempty"`
+	URN          URN          `xml:"id,attr,omitempty" json:"urn,omitempty"`
+	Name         string       `xml:"name,attr,omitempty" json:"name,omitempty"`
+	Type         string       `xml:"type,attr,omitempty" json:"type,omitempty"`
+	Scheduled    time.Time    `xml:"scheduled,attr,omitempty" json:"scheduled,omitempty"`
+	ScheduledEnd time.Time    `xml:"scheduled_end,attr,omitempty" json:"scheduled_end,omitempty"`
+	Status       string       `xml:"status,attr,omitempty" json:"status,omitempty"`
+	ReplacedBy   string       `xml:"replaced_by,attr,omitempty" json:"replacedBy,omitempty"`
+	Competitors  []Competitor `xml:"competitors>competitor,omitempty" json:"competitors,omitempty"`
 }
 
 func (f *Fixture) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
@@ -300,6 +351,85 @@ func (t *CompetitorPlayer) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 	return nil
 }
 
+func (t *SportEvent) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type T SportEvent
+	var overlay struct {
+		*T
+	}
+	overlay.T = (*T)(t)
+	if err := d.DecodeElement(&overlay, &start); err != nil {
+		return err
+	}
+	t.ID = t.URN.EventID()
+	return nil
+}
+
+func (t *ParentStage) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type T ParentStage
+	var overlay struct {
+		*T
+	}
+	overlay.T = (*T)(t)
+	if err := d.DecodeElement(&overlay, &start); err != nil {
+		return err
+	}
+	t.ID = t.URN.EventID()
+	return nil
+}
+
+func (t *Referee) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type T Referee
+	var overlay struct {
+		*T
+		URN URN `xml:"id,attr"`
+	}
+	overlay.T = (*T)(t)
+	if err := d.DecodeElement(&overlay, &start); err != nil {
+		return err
+	}
+	t.ID = overlay.URN.ID()
+	return nil
+}
+
+func (t *CoverageInfo) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type T CoverageInfo
+	var overlay struct {
+		*T
+		Coverage []struct {
+			Includes string `xml:"includes,attr"`
+		} `xml:"coverage"`
+	}
+	overlay.T = (*T)(t)
+	if err := d.DecodeElement(&overlay, &start); err != nil {
+		return err
+	}
+	for _, c := range overlay.Coverage {
+		t.Includes = append(t.Includes, c.Includes)
+	}
+	return nil
+}
+
+// ProductInfo flags are empty elements, flag is set if element is present.
+func (t *ProductInfo) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type T ProductInfo
+	var overlay struct {
+		*T
+		IsInLiveScore        *struct{} `xml:"is_in_live_score"`
+		IsInHostedStatistics *struct{} `xml:"is_in_hosted_statistics"`
+		IsInLiveCenterSoccer *struct{} `xml:"is_in_live_center_soccer"`
+		IsAutoTraded         *struct{} `xml:"is_auto_traded"`
+	}
+	overlay.T = (*T)(t)
+	if err := d.DecodeElement(&overlay, &start); err != nil {
+		return err
+	}
+	t.IsInLiveScore = overlay.IsInLiveScore != nil
+	t.IsInHostedStatistics = overlay.IsInHostedStatistics != nil
+	t.IsInLiveCenterSoccer = overlay.IsInLiveCenterSoccer != nil
+	t.IsAutoTraded = overlay.IsAutoTraded != nil
+	return nil
+}
+
 // PP pretty prints fixure row
 func (f *Fixture) PP() string {
 	name := fmt.Sprintf("%s - %s", f.Home.Name, f.Away.Name)
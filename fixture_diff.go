@@ -0,0 +1,114 @@
+package uof
+
+import "time"
+
+// FixtureDiff changes in the fixture between two api calls. Only changed
+// attributes are set.
+type FixtureDiff struct {
+	// hint from the fixture_change message which triggered the api call
+	ChangeType   *FixtureChangeType `json:"changeType,omitempty"`
+	StartTime    *TimeChange        `json:"startTime,omitempty"`
+	StartTimeTbd *BoolChange        `json:"startTimeTbd,omitempty"`
+	Competitors  *CompetitorsChange `json:"competitors,omitempty"`
+	Venue        *VenueChange       `json:"venue,omitempty"`
+	Status       *StringChange      `json:"status,omitempty"`
+	ReplacedBy   *StringChange      `json:"replacedBy,omitempty"`
+	Liveodds     *StringChange      `json:"liveodds,omitempty"`
+}
+
+type TimeChange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Moved returns how much start time is moved, negative if to earlier.
+func (c TimeChange) Moved() time.Duration {
+	return c.To.Sub(c.From)
+}
+
+type BoolChange struct {
+	From bool `json:"from"`
+	To   bool `json:"to"`
+}
+
+type StringChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type VenueChange struct {
+	From Venue `json:"from"`
+	To   Venue `json:"to"`
+}
+
+// CompetitorsChange competitors are identified by id.
+type CompetitorsChange struct {
+	Added   []Competitor `json:"added,omitempty"`
+	Removed []Competitor `json:"removed,omitempty"`
+}
+
+// Empty reports that none of the tracked attributes is changed.
+func (d FixtureDiff) Empty() bool {
+	return d.StartTime == nil && d.StartTimeTbd == nil && d.Competitors == nil &&
+		d.Venue == nil && d.Status == nil && d.ReplacedBy == nil && d.Liveodds == nil
+}
+
+// Replaced reports that event is replaced by another, ReplacedBy.To is urn
+// of the new event.
+func (d FixtureDiff) Replaced() bool {
+	return d.ReplacedBy != nil && d.ReplacedBy.To != ""
+}
+
+// Diff finds changes from prev to f.
+func (f Fixture) Diff(prev Fixture) FixtureDiff {
+	var d FixtureDiff
+	if !f.StartTime.Equal(prev.StartTime) {
+		d.StartTime = &TimeChange{From: prev.StartTime, To: f.StartTime}
+	}
+	if f.StartTimeTbd != prev.StartTimeTbd {
+		d.StartTimeTbd = &BoolChange{From: prev.StartTimeTbd, To: f.StartTimeTbd}
+	}
+	if c := diffCompetitors(prev.Competitors, f.Competitors); c != nil {
+		d.Competitors = c
+	}
+	if f.Venue != prev.Venue {
+		d.Venue = &VenueChange{From: prev.Venue, To: f.Venue}
+	}
+	d.Status = diffString(prev.Status, f.Status)
+	d.ReplacedBy = diffString(prev.ReplacedBy, f.ReplacedBy)
+	d.Liveodds = diffString(prev.Liveodds, f.Liveodds)
+	return d
+}
+
+func diffString(from, to string) *StringChange {
+	if from == to {
+		return nil
+	}
+	return &StringChange{From: from, To: to}
+}
+
+func diffCompetitors(from, to []Competitor) *CompetitorsChange {
+	ids := func(cs []Competitor) map[int]bool {
+		m := make(map[int]bool, len(cs))
+		for _, c := range cs {
+			m[c.ID] = true
+		}
+		return m
+	}
+	fromIDs, toIDs := ids(from), ids(to)
+	var c CompetitorsChange
+	for _, x := range to {
+		if !fromIDs[x.ID] {
+			c.Added = append(c.Added, x)
+		}
+	}
+	for _, x := range from {
+		if !toIDs[x.ID] {
+			c.Removed = append(c.Removed, x)
+		}
+	}
+	if c.Added == nil && c.Removed == nil {
+		return nil
+	}
+	return &c
+}
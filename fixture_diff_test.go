@@ -0,0 +1,50 @@
+package uof
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixtureDiff(t *testing.T) {
+	buf, err := ioutil.ReadFile("./testdata/fixture-0.xml")
+	assert.NoError(t, err)
+	var rsp FixtureRsp
+	assert.NoError(t, xml.Unmarshal(buf, &rsp))
+	prev := rsp.Fixture
+
+	assert.True(t, prev.Diff(prev).Empty())
+
+	f := prev
+	f.StartTime = prev.StartTime.Add(2 * time.Hour)
+	f.StartTimeTbd = true
+	f.Competitors = []Competitor{prev.Competitors[0], {ID: 1, Name: "Ajax Reserves"}}
+	f.Venue.Name = "Amsterdam Arena"
+	f.Status = "not_started"
+	f.ReplacedBy = "sr:match:1"
+	f.Liveodds = "booked"
+
+	d := f.Diff(prev)
+	assert.False(t, d.Empty())
+	assert.Equal(t, 2*time.Hour, d.StartTime.Moved())
+	assert.Equal(t, BoolChange{From: false, To: true}, *d.StartTimeTbd)
+	assert.Len(t, d.Competitors.Added, 1)
+	assert.Equal(t, 1, d.Competitors.Added[0].ID)
+	assert.Len(t, d.Competitors.Removed, 1)
+	assert.Equal(t, prev.Competitors[1].ID, d.Competitors.Removed[0].ID)
+	assert.Equal(t, "Johan Cruijff Arena", d.Venue.From.Name)
+	assert.Equal(t, "Amsterdam Arena", d.Venue.To.Name)
+	assert.Equal(t, StringChange{From: "closed", To: "not_started"}, *d.Status)
+	assert.True(t, d.Replaced())
+	assert.Equal(t, "sr:match:1", d.ReplacedBy.To)
+	assert.Equal(t, StringChange{From: "not_available", To: "booked"}, *d.Liveodds)
+	assert.Nil(t, d.ChangeType)
+
+	// competitors order is not a change
+	f = prev
+	f.Competitors = []Competitor{prev.Competitors[1], prev.Competitors[0]}
+	assert.True(t, f.Diff(prev).Empty())
+}
@@ -0,0 +1,59 @@
+// Package inflight coalesces concurrent calls for the same key into single
+// call.
+package inflight
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Group of the calls. Callers which arrive while the call for the key is in
+// progress wait for it and share its result. Finished calls are not cached.
+type Group struct {
+	// counters first for 64-bit alignment of atomic operations
+	hits   int64
+	misses int64
+
+	calls map[string]*call
+	sync.Mutex
+}
+
+type call struct {
+	wg  sync.WaitGroup
+	val interface{}
+	err error
+}
+
+func New() *Group {
+	return &Group{calls: make(map[string]*call)}
+}
+
+// Do calls fn, or waits for the call in progress for the same key.
+func (g *Group) Do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.Lock()
+	if c, ok := g.calls[key]; ok {
+		g.Unlock()
+		atomic.AddInt64(&g.hits, 1)
+		c.wg.Wait()
+		return c.val, c.err
+	}
+	c := &call{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.Unlock()
+	atomic.AddInt64(&g.misses, 1)
+
+	c.val, c.err = fn()
+	c.wg.Done()
+
+	g.Lock()
+	delete(g.calls, key)
+	g.Unlock()
+	return c.val, c.err
+}
+
+// Stats number of calls which joined the call in progress (hits), and which
+// made the call (misses).
+func (g *Group) Stats() (hits, misses int64) {
+	return atomic.LoadInt64(&g.hits), atomic.LoadInt64(&g.misses)
+}
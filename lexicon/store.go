@@ -0,0 +1,340 @@
+// Package lexicon keeps market descriptions, fixtures and players received
+// through the pipeline and makes them available for lookups.
+package lexicon
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/minus5/go-uof-sdk"
+	"github.com/minus5/go-uof-sdk/internal/inflight"
+	"github.com/minus5/go-uof-sdk/pipe"
+)
+
+// API used to fetch descriptions which are not found in the store.
+type API interface {
+	Markets(lang uof.Lang) (uof.MarketDescriptions, error)
+	MarketVariant(lang uof.Lang, marketID int, variant string) (uof.MarketDescriptions, error)
+	Fixture(lang uof.Lang, eventURN uof.URN) ([]byte, error)
+	Player(lang uof.Lang, playerID int) (*uof.Player, error)
+	DrawSummary(lang uof.Lang, eventURN uof.URN) ([]byte, error)
+}
+
+type marketKey struct {
+	lang uof.Lang
+	id   int
+}
+
+// Store of the lexicon messages. Safe for concurrent use.
+// Implements uof.Lexicon.
+type Store struct {
+	api     API
+	markets map[marketKey]*uof.MarketDescription
+	langs   map[uof.Lang]bool // languages for which all markets are loaded
+	// variant descriptions, dynamic variants are unique for the event
+	variants *pipe.Cache
+	fixtures *pipe.Cache
+	players  *pipe.Cache
+	draws    *pipe.Cache
+	// concurrent fetches of the same entry are coalesced into one api call
+	flight *inflight.Group
+	sync.RWMutex
+}
+
+// Option configures the store.
+type Option func(*options)
+
+type options struct {
+	cachePolicy pipe.CachePolicy
+}
+
+// WithCachePolicy sets TTL and size limit of the market variants, fixtures,
+// players and draws kept in the store. Zero values keep defaults: TTL of 24 hours and MaxSize
+// of 100000 for each.
+func WithCachePolicy(p pipe.CachePolicy) Option {
+	return func(o *options) {
+		o.cachePolicy = p
+	}
+}
+
+var errNotFound = errors.New("not found")
+
+// NewStore creates empty store. If api is not nil lookup which misses the
+// store makes blocking api call to fetch description. Market variants,
+// fixtures, players and draws are evicted after TTL, or when size limit is
+// reached; markets without variant are kept.
+func NewStore(api API, opts ...Option) *Store {
+	var o options
+	for _, fn := range opts {
+		fn(&o)
+	}
+	p := o.cachePolicy
+	return &Store{
+		api:      api,
+		markets:  make(map[marketKey]*uof.MarketDescription),
+		langs:    make(map[uof.Lang]bool),
+		variants: pipe.NewCache(p),
+		fixtures: pipe.NewCache(p),
+		players:  pipe.NewCache(p),
+		draws:    pipe.NewCache(p),
+		flight:   inflight.New(),
+	}
+}
+
+// FetchFrom sets api used for fetching descriptions not found in the store.
+func (s *Store) FetchFrom(api API) {
+	s.Lock()
+	defer s.Unlock()
+	s.api = api
+}
+
+// Stage fills store with the lexicon messages passing through the pipeline.
+func (s *Store) Stage() pipe.InnerStage {
+	return pipe.Simple(func(m *uof.Message) error {
+		s.Put(m)
+		return nil
+	})
+}
+
+// Put adds markets, fixture, player or draw message to the store. Other
+// messages are ignored.
+func (s *Store) Put(m *uof.Message) {
+	switch m.Type {
+	case uof.MessageTypeMarkets:
+		if m.MarketsDiff != nil {
+			s.removeMarkets(m.Lang, m.MarketsDiff.Removed)
+		}
+		s.putMarkets(m.Lang, m.Markets, m.MarketsAll)
+	case uof.MessageTypeFixture:
+		if m.Fixture != nil {
+			s.putFixture(m.Lang, m.Fixture)
+		}
+	case uof.MessageTypePlayer:
+		if m.Player != nil {
+			s.putPlayer(m.Lang, m.Player)
+		}
+	case uof.MessageTypeDraw:
+		if m.Draw != nil {
+			s.putDraw(m.Lang, m.Draw)
+		}
+	}
+}
+
+func (s *Store) putMarkets(lang uof.Lang, ms uof.MarketDescriptions, all bool) {
+	s.Lock()
+	defer s.Unlock()
+	for i := range ms {
+		md := ms[i]
+		if md.Variant != "" {
+			s.variants.Set(variantKey(lang, md.ID, md.Variant), &md)
+			continue
+		}
+		s.markets[marketKey{lang: lang, id: md.ID}] = &md
+	}
+	if all {
+		s.langs[lang] = true
+	}
+}
+
+func (s *Store) removeMarkets(lang uof.Lang, ms uof.MarketDescriptions) {
+	s.Lock()
+	defer s.Unlock()
+	for _, md := range ms {
+		delete(s.markets, marketKey{lang: lang, id: md.ID})
+	}
+}
+
+// variantKey of the market variant description in the variants cache
+func variantKey(lang uof.Lang, marketID int, variant string) int {
+	return uof.UIDWithLang(uof.Hash(fmt.Sprintf("%d/%s", marketID, variant)), lang)
+}
+
+// variant description from the cache, checked against hash collisions
+func (s *Store) variant(lang uof.Lang, marketID int, variant string) (*uof.MarketDescription, bool) {
+	v, ok := s.variants.Get(variantKey(lang, marketID, variant))
+	if !ok {
+		return nil, false
+	}
+	md := v.(*uof.MarketDescription)
+	if md.ID != marketID || md.Variant != variant {
+		return nil, false
+	}
+	return md, true
+}
+
+func (s *Store) putFixture(lang uof.Lang, f *uof.Fixture) {
+	s.fixtures.Set(uof.UIDWithLang(f.URN.EventID(), lang), f)
+}
+
+func (s *Store) putPlayer(lang uof.Lang, p *uof.Player) {
+	s.players.Set(uof.UIDWithLang(p.ID, lang), p)
+}
+
+func (s *Store) putDraw(lang uof.Lang, d *uof.Draw) {
+	s.draws.Set(uof.UIDWithLang(d.ID, lang), d)
+}
+
+func (s *Store) fetchAPI() API {
+	s.RLock()
+	defer s.RUnlock()
+	return s.api
+}
+
+// fetch calls fn once for the concurrent fetches with the same key
+func (s *Store) fetch(key string, fn func() (interface{}, error)) interface{} {
+	v, err := s.flight.Do(key, fn)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// Market description for the market id and variant. Variant is empty for
+// markets without variant specifier.
+// Returns nil if not found (or failed to fetch).
+func (s *Store) Market(lang uof.Lang, marketID int, variant string) *uof.MarketDescription {
+	if variant != "" {
+		if md, ok := s.variant(lang, marketID, variant); ok {
+			return md
+		}
+		api := s.fetchAPI()
+		if api == nil {
+			return nil
+		}
+		md := s.fetch(fmt.Sprintf("variant/%s/%d/%s", lang, marketID, variant), func() (interface{}, error) {
+			ms, err := api.MarketVariant(lang, marketID, variant)
+			if err != nil {
+				return nil, err
+			}
+			s.putMarkets(lang, ms, false)
+			for i := range ms {
+				if ms[i].ID == marketID && ms[i].Variant == variant {
+					return &ms[i], nil
+				}
+			}
+			return nil, errNotFound
+		})
+		if md == nil {
+			return nil
+		}
+		return md.(*uof.MarketDescription)
+	}
+
+	key := marketKey{lang: lang, id: marketID}
+	s.RLock()
+	md, ok := s.markets[key]
+	loaded := s.langs[lang]
+	api := s.api
+	s.RUnlock()
+	if ok || api == nil {
+		return md
+	}
+	if !loaded {
+		s.fetch(fmt.Sprintf("markets/%s", lang), func() (interface{}, error) {
+			ms, err := api.Markets(lang)
+			if err != nil {
+				return nil, err
+			}
+			s.putMarkets(lang, ms, true)
+			return nil, nil
+		})
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+	return s.markets[key]
+}
+
+// Fixture of the sport event. Returns nil if not found (or failed to fetch).
+func (s *Store) Fixture(lang uof.Lang, eventURN uof.URN) *uof.Fixture {
+	key := uof.UIDWithLang(eventURN.EventID(), lang)
+	if f, ok := s.fixtures.Get(key); ok {
+		return f.(*uof.Fixture)
+	}
+	api := s.fetchAPI()
+	if api == nil {
+		return nil
+	}
+
+	f := s.fetch(fmt.Sprintf("fixture/%d", key), func() (interface{}, error) {
+		buf, err := api.Fixture(lang, eventURN)
+		if err != nil {
+			return nil, err
+		}
+		m, err := uof.NewFixtureMessageFromBuf(lang, buf, uof.CurrentTimestamp())
+		if err != nil {
+			return nil, err
+		}
+		if m.Fixture == nil {
+			return nil, errNotFound
+		}
+		s.putFixture(lang, m.Fixture)
+		return m.Fixture, nil
+	})
+	if f == nil {
+		return nil
+	}
+	return f.(*uof.Fixture)
+}
+
+// Player profile. Returns nil if not found (or failed to fetch).
+func (s *Store) Player(lang uof.Lang, playerID int) *uof.Player {
+	key := uof.UIDWithLang(playerID, lang)
+	if p, ok := s.players.Get(key); ok {
+		return p.(*uof.Player)
+	}
+	api := s.fetchAPI()
+	if api == nil {
+		return nil
+	}
+
+	p := s.fetch(fmt.Sprintf("player/%d", key), func() (interface{}, error) {
+		p, err := api.Player(lang, playerID)
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			return nil, errNotFound
+		}
+		s.putPlayer(lang, p)
+		return p, nil
+	})
+	if p == nil {
+		return nil
+	}
+	return p.(*uof.Player)
+}
+
+// Draw of the numbers betting lottery. Returns nil if not found (or failed to
+// fetch).
+func (s *Store) Draw(lang uof.Lang, eventURN uof.URN) *uof.Draw {
+	key := uof.UIDWithLang(eventURN.EventID(), lang)
+	if d, ok := s.draws.Get(key); ok {
+		return d.(*uof.Draw)
+	}
+	api := s.fetchAPI()
+	if api == nil {
+		return nil
+	}
+
+	d := s.fetch(fmt.Sprintf("draw/%d", key), func() (interface{}, error) {
+		buf, err := api.DrawSummary(lang, eventURN)
+		if err != nil {
+			return nil, err
+		}
+		m, err := uof.NewDrawMessageFromBuf(lang, buf, uof.CurrentTimestamp())
+		if err != nil {
+			return nil, err
+		}
+		if m.Draw == nil {
+			return nil, errNotFound
+		}
+		s.putDraw(lang, m.Draw)
+		return m.Draw, nil
+	})
+	if d == nil {
+		return nil
+	}
+	return d.(*uof.Draw)
+}
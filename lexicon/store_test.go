@@ -0,0 +1,193 @@
+package lexicon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minus5/go-uof-sdk"
+	"github.com/minus5/go-uof-sdk/pipe"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ uof.Lexicon = (*Store)(nil)
+
+type apiMock struct {
+	calls map[string]int
+	sync.Mutex
+}
+
+func (a *apiMock) call(name string) {
+	a.Lock()
+	defer a.Unlock()
+	a.calls[name]++
+}
+
+func (a *apiMock) Markets(lang uof.Lang) (uof.MarketDescriptions, error) {
+	a.call("markets")
+	return uof.MarketDescriptions{{ID: 1, Name: "1x2"}, {ID: 18, Name: "Total"}}, nil
+}
+
+func (a *apiMock) MarketVariant(lang uof.Lang, marketID int, variant string) (uof.MarketDescriptions, error) {
+	a.call("variant")
+	if variant == "unknown" {
+		return nil, fmt.Errorf("not found")
+	}
+	return uof.MarketDescriptions{{ID: marketID, Variant: variant, Name: "Exact goals"}}, nil
+}
+
+func (a *apiMock) Fixture(lang uof.Lang, eventURN uof.URN) ([]byte, error) {
+	a.call("fixture")
+	return ioutil.ReadFile("../testdata/fixture-0.xml")
+}
+
+func (a *apiMock) Player(lang uof.Lang, playerID int) (*uof.Player, error) {
+	a.call("player")
+	return &uof.Player{ID: playerID, Name: "Kane, Harry"}, nil
+}
+
+func (a *apiMock) DrawSummary(lang uof.Lang, eventURN uof.URN) ([]byte, error) {
+	a.call("draw")
+	return ioutil.ReadFile("../testdata/draw-0.xml")
+}
+
+func TestStorePut(t *testing.T) {
+	s := NewStore(nil)
+	assert.Nil(t, s.Market(uof.LangEN, 1, ""))
+	assert.Nil(t, s.Fixture(uof.LangEN, "sr:match:1"))
+	assert.Nil(t, s.Player(uof.LangEN, 1))
+
+	s.Put(uof.NewMarketsMessage(uof.LangEN, uof.MarketDescriptions{
+		{ID: 1, Name: "1x2"},
+		{ID: 21, Variant: "sr:exact_goals:6+", Name: "Exact goals"},
+	}, 0))
+	s.Put(uof.NewFixtureMessage(uof.LangDE, uof.Fixture{URN: "sr:match:1", ID: 1, Name: "Spiel"}, 0))
+	s.Put(uof.NewPlayerMessage(uof.LangEN, &uof.Player{ID: 1, Name: "Kane, Harry"}, 0))
+	s.Put(uof.NewSimpleConnnectionMessage(uof.ConnectionStatusUp))
+
+	assert.Equal(t, "1x2", s.Market(uof.LangEN, 1, "").Name)
+	assert.Nil(t, s.Market(uof.LangDE, 1, ""))
+	assert.Nil(t, s.Market(uof.LangEN, 21, ""))
+	assert.Equal(t, "Exact goals", s.Market(uof.LangEN, 21, "sr:exact_goals:6+").Name)
+	assert.Equal(t, "Spiel", s.Fixture(uof.LangDE, "sr:match:1").Name)
+	assert.Nil(t, s.Fixture(uof.LangEN, "sr:match:1"))
+	assert.Equal(t, "Kane, Harry", s.Player(uof.LangEN, 1).Name)
+}
+
+func TestStoreFetch(t *testing.T) {
+	a := &apiMock{calls: make(map[string]int)}
+	s := NewStore(a)
+
+	assert.Equal(t, "1x2", s.Market(uof.LangEN, 1, "").Name)
+	assert.Equal(t, "Total", s.Market(uof.LangEN, 18, "").Name)
+	assert.Nil(t, s.Market(uof.LangEN, 2, ""))
+	assert.Equal(t, 1, a.calls["markets"])
+
+	assert.Equal(t, "Exact goals", s.Market(uof.LangEN, 21, "sr:exact_goals:6+").Name)
+	assert.Equal(t, "Exact goals", s.Market(uof.LangEN, 21, "sr:exact_goals:6+").Name)
+	assert.Nil(t, s.Market(uof.LangEN, 21, "unknown"))
+	assert.Equal(t, 2, a.calls["variant"])
+
+	f := s.Fixture(uof.LangEN, "sr:match:18001015")
+	assert.NotNil(t, f)
+	assert.Equal(t, "Ajax Amsterdam", f.Home.Name)
+	assert.Equal(t, f, s.Fixture(uof.LangEN, "sr:match:18001015"))
+	assert.Equal(t, 1, a.calls["fixture"])
+
+	assert.Equal(t, "Kane, Harry", s.Player(uof.LangEN, 833167).Name)
+	assert.Equal(t, "Kane, Harry", s.Player(uof.LangEN, 833167).Name)
+	assert.Equal(t, 1, a.calls["player"])
+
+	d := s.Draw(uof.LangEN, "wns:draw:1221541870")
+	assert.NotNil(t, d)
+	assert.Equal(t, "Lotto 6 aus 49", d.Lottery.Name)
+	assert.Equal(t, d, s.Draw(uof.LangEN, "wns:draw:1221541870"))
+	assert.Equal(t, 1, a.calls["draw"])
+}
+
+func TestStoreStage(t *testing.T) {
+	s := NewStore(nil)
+	in := make(chan *uof.Message)
+	out, errc := s.Stage()(in)
+	go func() {
+		in <- uof.NewPlayerMessage(uof.LangEN, &uof.Player{ID: 1, Name: "Kane, Harry"}, 0)
+		close(in)
+	}()
+	go func() {
+		for range errc {
+		}
+	}()
+	cnt := 0
+	for range out {
+		cnt++
+	}
+	assert.Equal(t, 1, cnt)
+	assert.NotNil(t, s.Player(uof.LangEN, 1))
+}
+
+type blockingAPIMock struct {
+	apiMock
+	release chan struct{}
+}
+
+func (a *blockingAPIMock) Player(lang uof.Lang, playerID int) (*uof.Player, error) {
+	<-a.release
+	return a.apiMock.Player(lang, playerID)
+}
+
+func TestStoreFetchCoalesced(t *testing.T) {
+	a := &blockingAPIMock{apiMock: apiMock{calls: make(map[string]int)}, release: make(chan struct{})}
+	s := NewStore(a)
+
+	var wg sync.WaitGroup
+	wg.Add(4)
+	for i := 0; i < 4; i++ {
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "Kane, Harry", s.Player(uof.LangEN, 833167).Name)
+		}()
+	}
+	// wait for all callers to join the first one
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		if hits, _ := s.flight.Stats(); hits == 3 || time.Now().After(deadline) {
+			break
+		}
+	}
+	close(a.release)
+	wg.Wait()
+	assert.Equal(t, 1, a.calls["player"])
+}
+
+func TestStoreEviction(t *testing.T) {
+	a := &apiMock{calls: make(map[string]int)}
+	s := NewStore(a, WithCachePolicy(pipe.CachePolicy{MaxSize: 1}))
+
+	assert.NotNil(t, s.Player(uof.LangEN, 1))
+	assert.NotNil(t, s.Player(uof.LangEN, 2))
+	assert.NotNil(t, s.Player(uof.LangEN, 2))
+	assert.Equal(t, 2, a.calls["player"])
+	// least recently used is evicted and fetched again
+	assert.NotNil(t, s.Player(uof.LangEN, 1))
+	assert.Equal(t, 3, a.calls["player"])
+}
+
+func TestStoreMarketsLoaded(t *testing.T) {
+	a := &apiMock{calls: make(map[string]int)}
+	s := NewStore(a, WithCachePolicy(pipe.CachePolicy{MaxSize: 1}))
+
+	// all markets from the pipeline, no need to fetch them
+	s.Put(uof.NewMarketsMessage(uof.LangEN, uof.MarketDescriptions{{ID: 1, Name: "1x2"}}, 0))
+	assert.Equal(t, "1x2", s.Market(uof.LangEN, 1, "").Name)
+	assert.Nil(t, s.Market(uof.LangEN, 18, ""))
+	assert.Equal(t, 0, a.calls["markets"])
+
+	// variants are evicted by the cache policy
+	s.Put(uof.NewMarketVariantMessage(uof.LangEN, "pre:playerprops:1:2", uof.MarketDescriptions{{ID: 768, Variant: "pre:playerprops:1:2"}}, 0))
+	s.Put(uof.NewMarketVariantMessage(uof.LangEN, "pre:playerprops:1:3", uof.MarketDescriptions{{ID: 768, Variant: "pre:playerprops:1:3"}}, 0))
+	assert.NotNil(t, s.Market(uof.LangEN, 768, "pre:playerprops:1:3"))
+	assert.Equal(t, 0, a.calls["variant"])
+	assert.NotNil(t, s.Market(uof.LangEN, 768, "pre:playerprops:1:2"))
+	assert.Equal(t, 1, a.calls["variant"])
+}
@@ -2,6 +2,7 @@ package uof
 
 import (
 	"encoding/xml"
+	"reflect"
 	"strings"
 )
 
@@ -36,6 +37,46 @@ func (md MarketDescriptions) Groups() map[string][]int {
 	return marketGroups
 }
 
+// MarketsDiff changes in the market descriptions between two api calls.
+type MarketsDiff struct {
+	Added   MarketDescriptions `json:"added,omitempty"`
+	Changed MarketDescriptions `json:"changed,omitempty"`
+	Removed MarketDescriptions `json:"removed,omitempty"`
+}
+
+func (d MarketsDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// Diff finds changes from prev to md. Markets are identified by id and
+// variant.
+func (md MarketDescriptions) Diff(prev MarketDescriptions) MarketsDiff {
+	type key struct{ id, variantID int }
+	pm := make(map[key]int, len(prev))
+	for i, m := range prev {
+		pm[key{m.ID, m.VariantID}] = i
+	}
+	var d MarketsDiff
+	for _, m := range md {
+		k := key{m.ID, m.VariantID}
+		i, ok := pm[k]
+		if !ok {
+			d.Added = append(d.Added, m)
+			continue
+		}
+		delete(pm, k)
+		if !reflect.DeepEqual(m, prev[i]) {
+			d.Changed = append(d.Changed, m)
+		}
+	}
+	for _, m := range prev {
+		if _, ok := pm[key{m.ID, m.VariantID}]; ok {
+			d.Removed = append(d.Removed, m)
+		}
+	}
+	return d
+}
+
 type MarketDescription struct {
 	ID                     int               `xml:"id,attr" json:"id"`
 	VariantID              int               `json:"variantID,omitempty"`
@@ -0,0 +1,37 @@
+package uof
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarketsDiff(t *testing.T) {
+	prev := MarketDescriptions{
+		{ID: 1, Name: "1x2", Groups: []string{"score"}},
+		{ID: 18, Name: "Total"},
+		{ID: 21, VariantID: 1, Name: "Exact goals"},
+		{ID: 21, VariantID: 2, Name: "Exact goals"},
+	}
+	curr := MarketDescriptions{
+		{ID: 1, Name: "1x2", Groups: []string{"score", "regular_play"}},
+		{ID: 21, VariantID: 1, Name: "Exact goals"},
+		{ID: 21, VariantID: 3, Name: "Exact goals"},
+		{ID: 16, Name: "Handicap"},
+	}
+	assert.True(t, curr.Diff(curr).Empty())
+
+	d := curr.Diff(prev)
+	assert.False(t, d.Empty())
+	assert.Equal(t, MarketDescriptions{curr[2], curr[3]}, d.Added)
+	assert.Equal(t, MarketDescriptions{curr[0]}, d.Changed)
+	assert.Equal(t, MarketDescriptions{prev[1], prev[3]}, d.Removed)
+
+	m := NewMarketsDiffMessage(LangEN, d, 1)
+	assert.Equal(t, MessageTypeMarkets, m.Type)
+	assert.Len(t, m.Markets, 3)
+	assert.Equal(t, &d, m.MarketsDiff)
+
+	d = curr.Diff(nil)
+	assert.Len(t, d.Added, 4)
+}
@@ -12,17 +12,19 @@ import (
 )
 
 type Header struct {
-	Type        MessageType     `json:"type,omitempty"`
-	Scope       MessageScope    `json:"scope,omitempty"`
-	Priority    MessagePriority `json:"priority,omitempty"`
-	Lang        Lang            `json:"lang,omitempty"`
-	SportID     int             `json:"sportID,omitempty"`
-	EventID     int             `json:"eventID,omitempty"`
-	EventURN    URN             `json:"eventURN,omitempty"`
-	ReceivedAt  int             `json:"receivedAt,omitempty"`
-	RequestedAt int             `json:"requestedAt,omitempty"`
-	Producer    Producer        `json:"producer,omitempty"`
-	Timestamp   int             `json:"timestamp,omitempty"`
+	Type     MessageType     `json:"type,omitempty"`
+	Scope    MessageScope    `json:"scope,omitempty"`
+	Priority MessagePriority `json:"priority,omitempty"`
+	Lang     Lang            `json:"lang,omitempty"`
+	SportID  int             `json:"sportID,omitempty"`
+	EventID  int             `json:"eventID,omitempty"`
+	EventURN URN             `json:"eventURN,omitempty"`
+	// parent stage of the race event, set in the fixture stage
+	ParentEventURN URN      `json:"parentEventURN,omitempty"`
+	ReceivedAt     int      `json:"receivedAt,omitempty"`
+	RequestedAt    int      `json:"requestedAt,omitempty"`
+	Producer       Producer `json:"producer,omitempty"`
+	Timestamp      int      `json:"timestamp,omitempty"`
 }
 
 type Body struct {
@@ -41,6 +43,17 @@ type Body struct {
 	Fixture *Fixture           `json:"fixture,omitempty"`
 	Markets MarketDescriptions `json:"markets,omitempty"`
 	Player  *Player            `json:"player,omitempty"`
+	Draw    *Draw              `json:"draw,omitempty"`
+	// set on markets refresh, Markets then contains only added and changed
+	MarketsDiff *MarketsDiff `json:"marketsDiff,omitempty"`
+	// set on market variant descriptions, Markets then contains only
+	// descriptions of that variant
+	MarketVariant string `json:"marketVariant,omitempty"`
+	// set on all markets descriptions for the language, Markets then
+	// replaces all previously received
+	MarketsAll bool `json:"marketsAll,omitempty"`
+	// set on fixture refetch, changes from the previous version
+	FixtureDiff *FixtureDiff `json:"fixtureDiff,omitempty"`
 	// sdk status message types
 	Connection *Connection     `json:"connection,omitempty"`
 	Producers  ProducersChange `json:"producerChange,omitempty"`
@@ -79,6 +92,8 @@ func timeToTimestamp(t time.Time) int {
 	return int(t.UnixNano()) / 1e6
 }
 
+// NewQueueMessage parses message received from the queue. Message with
+// unknown enum values is returned together with the notice error.
 func NewQueueMessage(routingKey string, body []byte) (*Message, error) {
 	r := &Message{
 		Header: Header{ReceivedAt: uniqTimestamp()},
@@ -87,7 +102,10 @@ func NewQueueMessage(routingKey string, body []byte) (*Message, error) {
 	if err := r.parseRoutingKey(routingKey); err != nil {
 		return nil, err
 	}
-	return r, r.unpack()
+	if err := r.unpack(); err != nil {
+		return nil, err
+	}
+	return r, r.OddsChange.Validate()
 }
 
 func (m *Message) parseRoutingKey(routingKey string) error {
@@ -206,6 +224,11 @@ func (m *Message) unpack() error {
 		pp := PlayerProfile{}
 		unmarshal(&pp)
 		m.Player = &pp.Player
+	case MessageTypeDraw:
+		dr := DrawRsp{}
+		unmarshal(&dr)
+		m.Timestamp = int(dr.GeneratedAt.UnixNano() / 1e6)
+		m.Draw = &dr.Draw
 	default:
 		err := fmt.Errorf("unknown message type %d", m.Type)
 		return Notice("message.unpack", err)
@@ -232,7 +255,15 @@ func NewAPIMessage(lang Lang, typ MessageType, body []byte) (*Message, error) {
 	return m, nil
 }
 
+// NewMarketsMessage creates markets message with the descriptions of all
+// markets for the language.
 func NewMarketsMessage(lang Lang, ms MarketDescriptions, requestedAt int) *Message {
+	m := newMarketsMessage(lang, ms, requestedAt)
+	m.MarketsAll = true
+	return m
+}
+
+func newMarketsMessage(lang Lang, ms MarketDescriptions, requestedAt int) *Message {
 	m := &Message{
 		Header: Header{
 			Type:        MessageTypeMarkets,
@@ -245,6 +276,25 @@ func NewMarketsMessage(lang Lang, ms MarketDescriptions, requestedAt int) *Messa
 	return m
 }
 
+// NewMarketVariantMessage creates markets message with the descriptions of
+// the market variant.
+func NewMarketVariantMessage(lang Lang, variant string, ms MarketDescriptions, requestedAt int) *Message {
+	m := newMarketsMessage(lang, ms, requestedAt)
+	m.MarketVariant = variant
+	return m
+}
+
+// NewMarketsDiffMessage creates markets message with the changes found on
+// markets refresh.
+func NewMarketsDiffMessage(lang Lang, d MarketsDiff, requestedAt int) *Message {
+	ms := make(MarketDescriptions, 0, len(d.Added)+len(d.Changed))
+	ms = append(ms, d.Added...)
+	ms = append(ms, d.Changed...)
+	m := newMarketsMessage(lang, ms, requestedAt)
+	m.MarketsDiff = &d
+	return m
+}
+
 func NewPlayerMessage(lang Lang, player *Player, requestedAt int) *Message {
 	return &Message{
 		Header: Header{
@@ -342,6 +392,28 @@ func NewFixtureMessageFromBuf(lang Lang, buf []byte, requestedAt int) (*Message,
 	return m, nil
 }
 
+// NewDrawMessageFromBuf creates uof.Message from draw fixture or summary API
+// response XML.
+func NewDrawMessageFromBuf(lang Lang, buf []byte, requestedAt int) (*Message, error) {
+	m := &Message{
+		Header: Header{
+			Type:        MessageTypeDraw,
+			Lang:        lang,
+			ReceivedAt:  uniqTimestamp(),
+			RequestedAt: requestedAt,
+		},
+		Raw: buf,
+	}
+	if err := m.unpack(); err != nil {
+		return nil, err
+	}
+	if m.Draw != nil {
+		m.EventURN = m.Draw.URN
+		m.EventID = m.Draw.ID
+	}
+	return m, nil
+}
+
 func (m *Message) NewFixtureMessage(lang Lang, f Fixture) *Message {
 	c := &Message{
 		Header: m.Header,
@@ -0,0 +1,309 @@
+package uof
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Lexicon provides descriptions needed to render market and outcome names.
+// Lookups return nil when description is not (yet) known.
+type Lexicon interface {
+	Market(lang Lang, marketID int, variant string) *MarketDescription
+	Fixture(lang Lang, eventURN URN) *Fixture
+	Player(lang Lang, playerID int) *Player
+}
+
+// NameProvider renders market and outcome names from the name templates in
+// the market descriptions.
+// Templates are replaced by:
+//
+//	{X}             value of the specifier X
+//	{+X}, {-X}      value of the specifier X with sign, - negates the value
+//	{!X}            ordinal of the specifier X value: 1st, 2nd...
+//	{(X+n)}         value of the specifier X plus n (also {!(X+n)}, {(X-n)}...)
+//	{$competitorN}  name of the Nth competitor of the event
+//	{$event}        name of the event
+//	{%X}            name of the player (or competitor) in the specifier X
+//
+// Reference: https://docs.betradar.com/display/BD/UOF+-+Market+and+outcome+names
+type NameProvider struct {
+	lx   Lexicon
+	lang Lang
+}
+
+// NewNameProvider creates name provider for the language.
+func NewNameProvider(lx Lexicon, lang Lang) *NameProvider {
+	return &NameProvider{lx: lx, lang: lang}
+}
+
+// MarketName renders name of the market line identified by market id and
+// specifiers.
+func (p *NameProvider) MarketName(eventURN URN, marketID int, specifiers map[string]string) (string, error) {
+	md, err := p.market(marketID, specifiers)
+	if err != nil {
+		return "", err
+	}
+	return p.render(md.Name, eventURN, specifiers)
+}
+
+// OutcomeName renders name of the outcome in the market line.
+func (p *NameProvider) OutcomeName(eventURN URN, marketID int, specifiers map[string]string, outcomeID int) (string, error) {
+	md, err := p.market(marketID, specifiers)
+	if err != nil {
+		return "", err
+	}
+	for _, o := range md.Outcomes {
+		if o.ID == outcomeID {
+			return p.render(o.Name, eventURN, specifiers)
+		}
+	}
+	return "", E("name", fmt.Errorf("outcome %d not found in market %d", outcomeID, marketID))
+}
+
+// OddsChangeOutcomeName resolves name of the outcome in the odds change
+// market. Player and competitor outcomes are named by the player or
+// competitor, others by the outcome template from the market description.
+func (p *NameProvider) OddsChangeOutcomeName(eventURN URN, m Market, o Outcome) (string, error) {
+	return p.outcomeName(eventURN, m.ID, m.Specifiers, o.ID, o.PlayerID, o.CompetitorIDs)
+}
+
+// BetSettlementOutcomeName resolves name of the outcome in the bet settlement
+// market.
+func (p *NameProvider) BetSettlementOutcomeName(eventURN URN, m BetSettlementMarket, o BetSettlementOutcome) (string, error) {
+	return p.outcomeName(eventURN, m.ID, m.Specifiers, o.ID, o.PlayerID, o.CompetitorIDs)
+}
+
+func (p *NameProvider) outcomeName(eventURN URN, marketID int, specifiers map[string]string, outcomeID, playerID int, competitorIDs []int) (string, error) {
+	if playerID != 0 {
+		return p.playerName(playerID, eventURN)
+	}
+	if len(competitorIDs) > 0 {
+		f := p.lx.Fixture(p.lang, eventURN)
+		if f == nil {
+			return "", E("name", fmt.Errorf("fixture %s not found", eventURN))
+		}
+		names := make([]string, 0, len(competitorIDs))
+		for _, id := range competitorIDs {
+			n, err := competitorName(f, id)
+			if err != nil {
+				return "", err
+			}
+			names = append(names, n)
+		}
+		return strings.Join(names, ", "), nil
+	}
+	// default and free text outcomes are listed in the (variant) description
+	return p.OutcomeName(eventURN, marketID, specifiers, outcomeID)
+}
+
+func (p *NameProvider) market(marketID int, specifiers map[string]string) (*MarketDescription, error) {
+	variant := specifiers["variant"]
+	md := p.lx.Market(p.lang, marketID, variant)
+	if md == nil {
+		return nil, E("name", fmt.Errorf("market %d variant '%s' description not found", marketID, variant))
+	}
+	return md, nil
+}
+
+func (p *NameProvider) render(tpl string, eventURN URN, specifiers map[string]string) (string, error) {
+	if !strings.Contains(tpl, "{") {
+		return tpl, nil
+	}
+	var sb strings.Builder
+	for {
+		i := strings.IndexByte(tpl, '{')
+		if i < 0 {
+			sb.WriteString(tpl)
+			break
+		}
+		j := strings.IndexByte(tpl[i:], '}')
+		if j < 0 {
+			return "", E("name", fmt.Errorf("unclosed expression in '%s'", tpl))
+		}
+		sb.WriteString(tpl[:i])
+		v, err := p.expression(tpl[i+1:i+j], eventURN, specifiers)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(v)
+		tpl = tpl[i+j+1:]
+	}
+	return sb.String(), nil
+}
+
+func (p *NameProvider) expression(expr string, eventURN URN, specifiers map[string]string) (string, error) {
+	if expr == "" {
+		return "", E("name", fmt.Errorf("empty expression"))
+	}
+	op, operand := expr[0], expr[1:]
+	switch op {
+	case '$':
+		return p.eventName(operand, eventURN)
+	case '%':
+		v, ok := specifiers[operand]
+		if !ok {
+			return "", E("name", fmt.Errorf("specifier %s not found", operand))
+		}
+		return p.entityName(v, eventURN)
+	case '!':
+		v, err := p.number(operand, specifiers)
+		if err != nil {
+			return "", err
+		}
+		return p.ordinal(int(v)), nil
+	case '+':
+		v, err := p.number(operand, specifiers)
+		if err != nil {
+			return "", err
+		}
+		return signed(v), nil
+	case '-':
+		v, err := p.number(operand, specifiers)
+		if err != nil {
+			return "", err
+		}
+		return signed(-v), nil
+	}
+	if strings.HasPrefix(expr, "(") {
+		v, err := p.number(expr, specifiers)
+		if err != nil {
+			return "", err
+		}
+		return formatNumber(v), nil
+	}
+	v, ok := specifiers[expr]
+	if !ok {
+		return "", E("name", fmt.Errorf("specifier %s not found", expr))
+	}
+	return v, nil
+}
+
+// number resolves operand in form X or (X+n) or (X-n) to the number
+func (p *NameProvider) number(operand string, specifiers map[string]string) (float64, error) {
+	var add float64
+	if strings.HasPrefix(operand, "(") && strings.HasSuffix(operand, ")") {
+		operand = operand[1 : len(operand)-1]
+		if i := strings.IndexAny(operand, "+-"); i > 0 {
+			n, err := strconv.ParseFloat(operand[i+1:], 64)
+			if err != nil {
+				return 0, E("name", fmt.Errorf("invalid operand in '%s'", operand))
+			}
+			if operand[i] == '-' {
+				n = -n
+			}
+			add = n
+			operand = operand[:i]
+		}
+	}
+	s, ok := specifiers[operand]
+	if !ok {
+		return 0, E("name", fmt.Errorf("specifier %s not found", operand))
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, E("name", fmt.Errorf("specifier %s value '%s' is not a number", operand, s))
+	}
+	return v + add, nil
+}
+
+func (p *NameProvider) eventName(name string, eventURN URN) (string, error) {
+	f := p.lx.Fixture(p.lang, eventURN)
+	if f == nil {
+		return "", E("name", fmt.Errorf("fixture %s not found", eventURN))
+	}
+	if name == "event" {
+		if f.Name != "" {
+			return f.Name, nil
+		}
+		return fmt.Sprintf("%s vs. %s", f.Home.Name, f.Away.Name), nil
+	}
+	if strings.HasPrefix(name, "competitor") {
+		n, err := strconv.Atoi(strings.TrimPrefix(name, "competitor"))
+		if err == nil && n > 0 && n <= len(f.Competitors) {
+			return f.Competitors[n-1].Name, nil
+		}
+	}
+	return "", E("name", fmt.Errorf("%s not found in fixture %s", name, eventURN))
+}
+
+// entityName of the player or competitor. Player specifiers are already
+// stripped of the sr:player: prefix.
+func (p *NameProvider) entityName(value string, eventURN URN) (string, error) {
+	u := URN(value)
+	if !strings.Contains(value, ":") {
+		u = URN(srPlayer + value)
+	}
+	if strings.HasPrefix(string(u), srPlayer) {
+		return p.playerName(u.ID(), eventURN)
+	}
+	f := p.lx.Fixture(p.lang, eventURN)
+	if f == nil {
+		return "", E("name", fmt.Errorf("fixture %s not found", eventURN))
+	}
+	return competitorName(f, u.ID())
+}
+
+// playerName from the player profile, falls back to the players listed in
+// the fixture
+func (p *NameProvider) playerName(id int, eventURN URN) (string, error) {
+	if pl := p.lx.Player(p.lang, id); pl != nil {
+		return pl.Name, nil
+	}
+	if f := p.lx.Fixture(p.lang, eventURN); f != nil {
+		for _, c := range f.Competitors {
+			for _, pl := range c.Players {
+				if pl.ID == id {
+					return pl.Name, nil
+				}
+			}
+		}
+	}
+	return "", E("name", fmt.Errorf("player %d not found", id))
+}
+
+func competitorName(f *Fixture, id int) (string, error) {
+	for _, c := range f.Competitors {
+		if c.ID == id {
+			return c.Name, nil
+		}
+	}
+	return "", E("name", fmt.Errorf("competitor %d not found in fixture %s", id, f.URN))
+}
+
+// ordinal number, English suffixes for English, dot after number for other
+// languages
+func (p *NameProvider) ordinal(n int) string {
+	if p.lang != LangEN {
+		return fmt.Sprintf("%d.", n)
+	}
+	suffix := "th"
+	switch n % 100 {
+	case 11, 12, 13:
+	default:
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+func signed(v float64) string {
+	if v > 0 {
+		return "+" + formatNumber(v)
+	}
+	return formatNumber(v)
+}
+
+func formatNumber(v float64) string {
+	if v == 0 {
+		// avoid -0
+		return "0"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,181 @@
+package uof
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type lexiconMock struct {
+	markets MarketDescriptions
+	fixture *Fixture
+	players map[int]*Player
+}
+
+func (l *lexiconMock) Market(lang Lang, marketID int, variant string) *MarketDescription {
+	for _, m := range l.markets {
+		if m.ID == marketID && m.Variant == variant {
+			return &m
+		}
+	}
+	return nil
+}
+
+func (l *lexiconMock) Fixture(lang Lang, eventURN URN) *Fixture {
+	if l.fixture != nil && l.fixture.URN == eventURN {
+		return l.fixture
+	}
+	return nil
+}
+
+func (l *lexiconMock) Player(lang Lang, playerID int) *Player {
+	return l.players[playerID]
+}
+
+func newLexiconMock(t *testing.T) *lexiconMock {
+	buf, err := ioutil.ReadFile("./testdata/markets-1.xml")
+	assert.NoError(t, err)
+	ms := &MarketsRsp{}
+	assert.NoError(t, xml.Unmarshal(buf, ms))
+
+	buf, err = ioutil.ReadFile("./testdata/fixture-0.xml")
+	assert.NoError(t, err)
+	fr := &FixtureRsp{}
+	assert.NoError(t, xml.Unmarshal(buf, fr))
+
+	ms.Markets = append(ms.Markets,
+		MarketDescription{ID: 891, Name: "{!goalnr} goalscorer {%player}",
+			Outcomes: []MarketOutcome{{ID: 74, Name: "yes"}}},
+		MarketDescription{ID: 165, Name: "Corner handicap {(hcp+1)} {!(goalnr+1)} {$event}"},
+		MarketDescription{ID: 200, Name: "{%server} to serve {!setnr} set"},
+	)
+	return &lexiconMock{
+		markets: ms.Markets,
+		fixture: &fr.Fixture,
+		players: map[int]*Player{833167: {ID: 833167, Name: "Kane, Harry"}},
+	}
+}
+
+func TestNameProvider(t *testing.T) {
+	np := NewNameProvider(newLexiconMock(t), LangEN)
+	event := URN("sr:match:18001015")
+
+	data := []struct {
+		marketID   int
+		specifiers map[string]string
+		outcomeID  int
+		market     string
+		outcome    string
+	}{
+		{1, nil, 1, "1x2", "Ajax Amsterdam"},
+		{1, nil, 2, "1x2", "draw"},
+		{10, nil, 10, "Double chance", "Ajax Amsterdam or Tottenham Hotspur"},
+		{16, map[string]string{"hcp": "1.5"}, 1714, "Handicap", "Ajax Amsterdam (+1.5)"},
+		{16, map[string]string{"hcp": "1.5"}, 1715, "Handicap", "Tottenham Hotspur (-1.5)"},
+		{16, map[string]string{"hcp": "-0.25"}, 1715, "Handicap", "Tottenham Hotspur (+0.25)"},
+		{16, map[string]string{"hcp": "0"}, 1714, "Handicap", "Ajax Amsterdam (0)"},
+		{18, map[string]string{"total": "2.5"}, 12, "Ukupno golova", "više od 2.5"},
+		{891, map[string]string{"goalnr": "2", "player": "833167"}, 74, "2nd goalscorer Kane, Harry", "yes"},
+	}
+	for _, d := range data {
+		n, err := np.MarketName(event, d.marketID, d.specifiers)
+		assert.NoError(t, err)
+		assert.Equal(t, d.market, n)
+		n, err = np.OutcomeName(event, d.marketID, d.specifiers, d.outcomeID)
+		assert.NoError(t, err)
+		assert.Equal(t, d.outcome, n)
+	}
+
+	n, err := np.MarketName(event, 165, map[string]string{"hcp": "-1", "goalnr": "10"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Corner handicap 0 11th Ajax Amsterdam vs. Tottenham Hotspur", n)
+
+	n, err = np.MarketName(event, 200, map[string]string{"server": "sr:competitor:33", "setnr": "3"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Tottenham Hotspur to serve 3rd set", n)
+
+	n, err = NewNameProvider(np.lx, LangDE).MarketName(event, 200, map[string]string{"server": "sr:competitor:33", "setnr": "3"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Tottenham Hotspur to serve 3. set", n)
+}
+
+func TestNameProviderErrors(t *testing.T) {
+	np := NewNameProvider(newLexiconMock(t), LangEN)
+	event := URN("sr:match:18001015")
+
+	_, err := np.MarketName(event, 999, nil)
+	assert.Error(t, err)
+	_, err = np.MarketName(event, 891, map[string]string{"goalnr": "1"})
+	assert.Error(t, err)
+	_, err = np.MarketName(event, 891, map[string]string{"goalnr": "1", "player": "1"})
+	assert.Error(t, err)
+	_, err = np.OutcomeName(event, 1, nil, 4)
+	assert.Error(t, err)
+	_, err = np.OutcomeName("sr:match:1", 1, nil, 1)
+	assert.Error(t, err)
+	_, err = np.OutcomeName(event, 16, map[string]string{"hcp": "x"}, 1714)
+	assert.Error(t, err)
+}
+
+func TestOrdinal(t *testing.T) {
+	np := NewNameProvider(nil, LangEN)
+	for n, s := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th",
+		12: "12th", 13: "13th", 21: "21st", 22: "22nd", 101: "101st", 111: "111th"} {
+		assert.Equal(t, s, np.ordinal(n))
+	}
+}
+
+func TestOutcomeNames(t *testing.T) {
+	lx := newLexiconMock(t)
+	lx.markets = append(lx.markets,
+		MarketDescription{ID: 40, Name: "Anytime goalscorer", OutcomeType: OutcomeTypePlayer},
+		MarketDescription{ID: 534, Name: "Winner", Variant: "pre:outcometext:9919", OutcomeType: OutcomeTypeFreeText,
+			Outcomes: []MarketOutcome{{ID: toOutcomeID("pre:outcometext:5"), Name: "Ajax or Tottenham"}}},
+	)
+	np := NewNameProvider(lx, LangEN)
+	event := URN("sr:match:18001015")
+
+	var m Market
+	buf := `<market id="40">
+		<outcome id="sr:player:833167" odds="5.1"/>
+		<outcome id="sr:player:1" odds="5.1"/>
+	</market>`
+	assert.NoError(t, xml.Unmarshal([]byte(buf), &m))
+	n, err := np.OddsChangeOutcomeName(event, m, m.Outcomes[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "Kane, Harry", n)
+	_, err = np.OddsChangeOutcomeName(event, m, m.Outcomes[1])
+	assert.Error(t, err)
+
+	buf = `<market id="534" specifiers="variant=pre:outcometext:9919">
+		<outcome id="sr:competitor:33" odds="1.2"/>
+		<outcome id="sr:competitor:2953,sr:competitor:33" odds="1.2"/>
+		<outcome id="pre:outcometext:5" odds="1.2"/>
+	</market>`
+	m = Market{}
+	assert.NoError(t, xml.Unmarshal([]byte(buf), &m))
+	assert.Equal(t, []int{33}, m.Outcomes[0].CompetitorIDs)
+	assert.Equal(t, []int{2953, 33}, m.Outcomes[1].CompetitorIDs)
+	expected := []string{"Tottenham Hotspur", "Ajax Amsterdam, Tottenham Hotspur", "Ajax or Tottenham"}
+	for i, o := range m.Outcomes {
+		n, err := np.OddsChangeOutcomeName(event, m, o)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[i], n)
+	}
+
+	var sm BetSettlementMarket
+	buf = `<market id="534" specifiers="variant=pre:outcometext:9919">
+		<outcome id="sr:competitor:2953" result="1"/>
+		<outcome id="pre:outcometext:5" result="0"/>
+	</market>`
+	assert.NoError(t, xml.Unmarshal([]byte(buf), &sm))
+	assert.Equal(t, "pre:outcometext:9919", sm.VariantSpecifier())
+	n, err = np.BetSettlementOutcomeName(event, sm, sm.Outcomes[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "Ajax Amsterdam", n)
+	n, err = np.BetSettlementOutcomeName(event, sm, sm.Outcomes[1])
+	assert.NoError(t, err)
+	assert.Equal(t, "Ajax or Tottenham", n)
+}
@@ -0,0 +1,124 @@
+// Package odds converts decimal odds from the feed to other odds formats and
+// calculates market margin and probabilities.
+package odds
+
+import (
+	"fmt"
+	"math"
+)
+
+// Fraction fractional (UK) odds, Num/Den is the profit for the unit stake.
+type Fraction struct {
+	Num int `json:"num"`
+	Den int `json:"den"`
+}
+
+func (f Fraction) String() string {
+	return fmt.Sprintf("%d/%d", f.Num, f.Den)
+}
+
+// Decimal odds of the fraction.
+func (f Fraction) Decimal() float64 {
+	if f.Den == 0 {
+		return 0
+	}
+	return 1 + float64(f.Num)/float64(f.Den)
+}
+
+// ladder of the fractional odds commonly used by bookmakers
+var ladder = []Fraction{
+	{1, 100}, {1, 66}, {1, 50}, {1, 40}, {1, 33}, {1, 25}, {1, 20}, {1, 16},
+	{1, 14}, {1, 12}, {1, 10}, {1, 9}, {1, 8}, {2, 15}, {1, 7}, {2, 13},
+	{1, 6}, {2, 11}, {1, 5}, {2, 9}, {1, 4}, {2, 7}, {3, 10}, {1, 3},
+	{4, 11}, {2, 5}, {4, 9}, {1, 2}, {8, 15}, {4, 7}, {8, 13}, {4, 6},
+	{8, 11}, {4, 5}, {5, 6}, {10, 11}, {1, 1}, {11, 10}, {6, 5}, {5, 4},
+	{11, 8}, {6, 4}, {13, 8}, {7, 4}, {15, 8}, {2, 1}, {9, 4}, {5, 2},
+	{11, 4}, {3, 1}, {10, 3}, {7, 2}, {4, 1}, {9, 2}, {5, 1}, {11, 2},
+	{6, 1}, {13, 2}, {7, 1}, {15, 2}, {8, 1}, {17, 2}, {9, 1}, {10, 1},
+	{11, 1}, {12, 1}, {14, 1}, {16, 1}, {18, 1}, {20, 1}, {25, 1}, {33, 1},
+	{40, 1}, {50, 1}, {66, 1}, {80, 1}, {100, 1}, {150, 1}, {200, 1},
+	{250, 1}, {500, 1}, {1000, 1},
+}
+
+// ToFraction returns the closest fraction from the standard ladder. Zero
+// fraction is returned for invalid odds (not greater than 1).
+func ToFraction(d float64) Fraction {
+	if !valid(d) {
+		return Fraction{}
+	}
+	best := ladder[0]
+	diff := math.Abs(best.Decimal() - d)
+	for _, f := range ladder[1:] {
+		if x := math.Abs(f.Decimal() - d); x < diff {
+			best, diff = f, x
+		}
+	}
+	return best
+}
+
+// ToAmerican (moneyline) odds: positive is profit on 100 stake, negative is
+// stake needed to win 100. Returns 0 for invalid odds.
+func ToAmerican(d float64) int {
+	if !valid(d) {
+		return 0
+	}
+	if d >= 2 {
+		return int(math.Round((d - 1) * 100))
+	}
+	return -int(math.Round(100 / (d - 1)))
+}
+
+// FromAmerican converts american odds to decimal.
+func FromAmerican(a int) float64 {
+	switch {
+	case a >= 100:
+		return 1 + float64(a)/100
+	case a <= -100:
+		return 1 + 100/float64(-a)
+	}
+	return 0
+}
+
+// ToHongKong odds are profit on the unit stake.
+func ToHongKong(d float64) float64 {
+	if !valid(d) {
+		return 0
+	}
+	return d - 1
+}
+
+// ToIndonesian odds are positive for the underdog (same as Hong Kong) and
+// negative for the favourite (stake needed to win 1).
+func ToIndonesian(d float64) float64 {
+	if !valid(d) {
+		return 0
+	}
+	if d >= 2 {
+		return d - 1
+	}
+	return -1 / (d - 1)
+}
+
+// ToMalay odds are positive for the favourite (same as Hong Kong) and
+// negative for the underdog.
+func ToMalay(d float64) float64 {
+	if !valid(d) {
+		return 0
+	}
+	if d <= 2 {
+		return d - 1
+	}
+	return -1 / (d - 1)
+}
+
+// Implied probability of the decimal odds.
+func Implied(d float64) float64 {
+	if !valid(d) {
+		return 0
+	}
+	return 1 / d
+}
+
+func valid(d float64) bool {
+	return d > 1 && !math.IsInf(d, 0)
+}
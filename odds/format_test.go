@@ -0,0 +1,58 @@
+package odds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToFraction(t *testing.T) {
+	data := []struct {
+		d float64
+		f string
+	}{
+		{2, "1/1"},
+		{1.5, "1/2"},
+		{3.5, "5/2"},
+		{1.91, "10/11"},
+		{1.01, "1/100"},
+		{2.62, "13/8"},
+		{11, "10/1"},
+		{5000, "1000/1"},
+		{1, "0/0"},
+	}
+	for _, d := range data {
+		assert.Equal(t, d.f, ToFraction(d.d).String(), "%v", d.d)
+	}
+	assert.Equal(t, 3.5, Fraction{5, 2}.Decimal())
+	assert.Equal(t, 0.0, Fraction{}.Decimal())
+}
+
+func TestFormats(t *testing.T) {
+	data := []struct {
+		d        float64
+		american int
+		hk       float64
+		indo     float64
+		malay    float64
+	}{
+		{2, 100, 1, 1, 1},
+		{3.5, 250, 2.5, 2.5, -0.4},
+		{1.5, -200, 0.5, -2, 0.5},
+		{1.25, -400, 0.25, -4, 0.25},
+		{1, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+	for _, d := range data {
+		assert.Equal(t, d.american, ToAmerican(d.d), "%v", d.d)
+		assert.InDelta(t, d.hk, ToHongKong(d.d), 1e-9, "%v", d.d)
+		assert.InDelta(t, d.indo, ToIndonesian(d.d), 1e-9, "%v", d.d)
+		assert.InDelta(t, d.malay, ToMalay(d.d), 1e-9, "%v", d.d)
+	}
+
+	assert.Equal(t, 3.5, FromAmerican(250))
+	assert.Equal(t, 1.5, FromAmerican(-200))
+	assert.Equal(t, 0.0, FromAmerican(50))
+	assert.Equal(t, 0.25, Implied(4))
+	assert.Equal(t, 0.0, Implied(0.5))
+}
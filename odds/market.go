@@ -0,0 +1,86 @@
+package odds
+
+import (
+	"github.com/minus5/go-uof-sdk"
+)
+
+// Active reports whether outcome can be priced: it has valid odds and is not
+// marked inactive. Outcome without active attribute is active.
+func Active(o uof.Outcome) bool {
+	if o.Active != nil && !*o.Active {
+		return false
+	}
+	return o.Odds != nil && valid(*o.Odds)
+}
+
+// Overround sum of the implied probabilities of the active outcomes. Returns
+// 0 if there are no active outcomes.
+func Overround(m uof.Market) float64 {
+	var s float64
+	for _, o := range m.Outcomes {
+		if Active(o) {
+			s += Implied(*o.Odds)
+		}
+	}
+	return s
+}
+
+// Margin bookmaker's margin, overround above 1.
+func Margin(m uof.Market) float64 {
+	o := Overround(m)
+	if o == 0 {
+		return 0
+	}
+	return o - 1
+}
+
+// ImpliedProbabilities of the active outcomes by the outcome id.
+func ImpliedProbabilities(m uof.Market) map[int]float64 {
+	p := make(map[int]float64)
+	for _, o := range m.Outcomes {
+		if Active(o) {
+			p[o.ID] = Implied(*o.Odds)
+		}
+	}
+	return p
+}
+
+// FairProbabilities implied probabilities with margin removed (normalized to
+// sum 1), by the outcome id.
+func FairProbabilities(m uof.Market) map[int]float64 {
+	p := ImpliedProbabilities(m)
+	var s float64
+	for _, v := range p {
+		s += v
+	}
+	for k, v := range p {
+		p[k] = v / s
+	}
+	return p
+}
+
+// FairOdds odds of the outcome without the margin. Returns 0 if outcome is
+// not active.
+func FairOdds(m uof.Market, outcomeID int) float64 {
+	p, ok := FairProbabilities(m)[outcomeID]
+	if !ok || p == 0 {
+		return 0
+	}
+	return 1 / p
+}
+
+// Favourite active outcome with the lowest odds. First one is returned when
+// more outcomes have the same odds.
+func Favourite(m uof.Market) (uof.Outcome, bool) {
+	var fav uof.Outcome
+	found := false
+	for _, o := range m.Outcomes {
+		if !Active(o) {
+			continue
+		}
+		if !found || *o.Odds < *fav.Odds {
+			fav, found = o, true
+		}
+	}
+	return fav, found
+}
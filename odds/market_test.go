@@ -0,0 +1,57 @@
+package odds
+
+import (
+	"testing"
+
+	"github.com/minus5/go-uof-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func outcome(id int, odds float64, active bool) uof.Outcome {
+	return uof.Outcome{ID: id, Odds: &odds, Active: &active}
+}
+
+func TestMarket(t *testing.T) {
+	m := uof.Market{
+		ID: 1,
+		Outcomes: []uof.Outcome{
+			outcome(1, 2.5, true),
+			outcome(2, 3.2, true),
+			outcome(3, 2.8, true),
+			// inactive and without odds are ignored
+			outcome(4, 1.1, false),
+			{ID: 5},
+		},
+	}
+	o := 1/2.5 + 1/3.2 + 1/2.8
+	assert.InDelta(t, o, Overround(m), 1e-9)
+	assert.InDelta(t, o-1, Margin(m), 1e-9)
+
+	ip := ImpliedProbabilities(m)
+	assert.Len(t, ip, 3)
+	assert.InDelta(t, 0.4, ip[1], 1e-9)
+
+	fp := FairProbabilities(m)
+	assert.Len(t, fp, 3)
+	assert.InDelta(t, 0.4/o, fp[1], 1e-9)
+	assert.InDelta(t, 1, fp[1]+fp[2]+fp[3], 1e-9)
+	assert.InDelta(t, 2.5*o, FairOdds(m, 1), 1e-9)
+	assert.Equal(t, 0.0, FairOdds(m, 4))
+
+	f, ok := Favourite(m)
+	assert.True(t, ok)
+	assert.Equal(t, 1, f.ID)
+
+	assert.True(t, Active(uof.Outcome{Odds: m.Outcomes[0].Odds}))
+	assert.False(t, Active(m.Outcomes[3]))
+	assert.False(t, Active(m.Outcomes[4]))
+}
+
+func TestMarketEmpty(t *testing.T) {
+	m := uof.Market{Outcomes: []uof.Outcome{outcome(1, 1.5, false)}}
+	assert.Equal(t, 0.0, Overround(m))
+	assert.Equal(t, 0.0, Margin(m))
+	assert.Len(t, FairProbabilities(m), 0)
+	_, ok := Favourite(m)
+	assert.False(t, ok)
+}
@@ -2,6 +2,7 @@ package uof
 
 import (
 	"encoding/xml"
+	"fmt"
 	"strconv"
 	"strings"
 )
@@ -20,10 +21,10 @@ type OddsChange struct {
 	Timestamp int      `xml:"timestamp,attr" json:"timestamp"`
 	Markets   []Market `json:"market,omitempty"`
 	// values in range 0-6   /v1/descriptions/betting_status.xml
-	BettingStatus *int `json:"bettingStatus,omitempty"`
+	BettingStatus *BettingStatus `json:"bettingStatus,omitempty"`
 	// values in range 0-87  /v1/descriptions/betstop_reasons.xml
-	BetstopReason    *int              `json:"betstopReason,omitempty"`
-	OddsChangeReason *int              `xml:"odds_change_reason,attr,omitempty" json:"oddsChangeReason,omitempty"` // May be one of 1
+	BetstopReason    *BetstopReason    `json:"betstopReason,omitempty"`
+	OddsChangeReason *OddsChangeReason `xml:"odds_change_reason,attr,omitempty" json:"oddsChangeReason,omitempty"` // May be one of 1
 	EventStatus      *SportEventStatus `xml:"sport_event_status,omitempty" json:"sportEventStatus,omitempty"`
 
 	OddsGenerationProperties *OddsGenerationProperties `xml:"odds_generation_properties,omitempty" json:"oddsGenerationProperties,omitempty"`
@@ -48,12 +49,17 @@ type OddsGenerationProperties struct {
 // ((goals=1.5)) that uniquely identifies them).
 // LineID is hash of specifier field used to uniquely identify lines in one market.
 // One market line is uniquely identified by market id and line id.
+// LineID is hash of the specifiers as they are in the feed, so it depends on
+// their order. LineSpecifiers is canonical (sorted) specifiers field, use
+// LineKey when order independent identity is needed or LineID hash collisions
+// are not acceptable.
 type Market struct {
-	ID            int               `xml:"id,attr" json:"id"`
-	LineID        int               `json:"lineID"`
-	Specifiers    map[string]string `json:"sepcifiers,omitempty"`
-	Status        MarketStatus      `xml:"status,attr,omitempty" json:"status,omitempty"`
-	CashoutStatus *CashoutStatus    `xml:"cashout_status,attr,omitempty" json:"cashoutStatus,omitempty"`
+	ID             int            `xml:"id,attr" json:"id"`
+	LineID         int            `json:"lineID"`
+	LineSpecifiers string         `xml:"-" json:"lineSpecifiers,omitempty"`
+	Specifiers     Specifiers     `json:"sepcifiers,omitempty"`
+	Status         MarketStatus   `xml:"status,attr,omitempty" json:"status,omitempty"`
+	CashoutStatus  *CashoutStatus `xml:"cashout_status,attr,omitempty" json:"cashoutStatus,omitempty"`
 	// If present, this is set to 1, which states that this is the most balanced
 	// or recommended market line. This setting makes most sense for markets where
 	// multiple lines are provided (e.g. the Totals market).
@@ -68,8 +74,11 @@ type MarketMetadata struct {
 }
 
 type Outcome struct {
-	ID            int      `json:"id"`
+	ID int `json:"id"`
+	// original outcome id when it is not integer
+	URN           string   `xml:"-" json:"urn,omitempty"`
 	PlayerID      int      `json:"playerID"`
+	CompetitorIDs []int    `json:"competitorIDs,omitempty"`
 	Odds          *float64 `xml:"odds,attr,omitempty" json:"odds,omitempty"`
 	Probabilities *float64 `xml:"probabilities,attr,omitempty" json:"probabilities,omitempty"`
 	Active        *bool    `xml:"active,attr,omitempty" json:"active,omitempty"`
@@ -92,8 +101,14 @@ func (o *OddsChange) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error
 		return err
 	}
 	if overlay.Odds != nil {
-		o.BettingStatus = overlay.Odds.BettingStatus
-		o.BetstopReason = overlay.Odds.BetstopReason
+		if v := overlay.Odds.BettingStatus; v != nil {
+			s := BettingStatus(*v)
+			o.BettingStatus = &s
+		}
+		if v := overlay.Odds.BetstopReason; v != nil {
+			r := BetstopReason(*v)
+			o.BetstopReason = &r
+		}
 		o.Markets = overlay.Odds.Markets
 	}
 	o.EventID = o.EventURN.EventID()
@@ -127,6 +142,7 @@ func (m *Market) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	}
 	m.Specifiers = toSpecifiers(overlay.Specifiers, overlay.ExtendedSpecifiers)
 	m.LineID = toLineID(overlay.Specifiers)
+	m.LineSpecifiers = canonicalSpecifiers(overlay.Specifiers)
 	if overlay.MarketMetadata != nil {
 		m.NextBetstop = overlay.MarketMetadata.NextBetstop
 	}
@@ -144,7 +160,9 @@ func (t *Outcome) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		return err
 	}
 	t.ID = toOutcomeID(overlay.ID)
+	t.URN = toOutcomeURN(overlay.ID)
 	t.PlayerID = toPlayerID(overlay.ID)
+	t.CompetitorIDs = toCompetitorIDs(overlay.ID)
 	return nil
 }
 
@@ -157,7 +175,7 @@ func (m Market) VariantSpecifier() string {
 	return ""
 }
 
-func toSpecifiers(specifiers, extendedSpecifiers string) map[string]string {
+func toSpecifiers(specifiers, extendedSpecifiers string) Specifiers {
 	allSpecifiers := specifiers
 	if extendedSpecifiers != "" {
 		allSpecifiers = allSpecifiers + "|" + extendedSpecifiers
@@ -165,7 +183,7 @@ func toSpecifiers(specifiers, extendedSpecifiers string) map[string]string {
 	if len(allSpecifiers) < 2 {
 		return nil
 	}
-	sm := make(map[string]string)
+	sm := make(Specifiers)
 	for _, s := range strings.Split(allSpecifiers, "|") {
 		if p := strings.Split(s, "="); len(p) == 2 {
 			k := p[0]
@@ -179,15 +197,34 @@ func toSpecifiers(specifiers, extendedSpecifiers string) map[string]string {
 	return sm
 }
 
+// toPlayerID extracts player id from the outcome id. Player props outcomes
+// have additional parts after the player id: sr:player:123:1
 func toPlayerID(id string) int {
-	if strings.HasPrefix(id, srPlayer) {
-		return URN(id).ID()
+	if !strings.HasPrefix(id, srPlayer) {
+		return 0
 	}
-	return 0
+	p := strings.SplitN(strings.TrimPrefix(id, srPlayer), ":", 2)
+	i, _ := strconv.ParseUint(p[0], 10, 64)
+	return int(i)
+}
+
+// toCompetitorIDs extracts ids of the competitor and competitors outcomes:
+// sr:competitor:123 or sr:competitor:123,sr:competitor:456
+func toCompetitorIDs(id string) []int {
+	if !strings.HasPrefix(id, srCompetitor) {
+		return nil
+	}
+	var ids []int
+	for _, p := range strings.Split(id, ",") {
+		if i := URN(p).ID(); i != 0 {
+			ids = append(ids, i)
+		}
+	}
+	return ids
 }
 
 func toOutcomeID(id string) int {
-	if strings.HasPrefix(id, srPlayer) {
+	if strings.HasPrefix(id, srPlayer) && strings.Count(id, ":") == 2 {
 		return toPlayerID(id)
 	}
 	if i, err := strconv.ParseInt(id, 10, 64); err == nil {
@@ -196,6 +233,36 @@ func toOutcomeID(id string) int {
 	return hash32(id)
 }
 
+// IsRiskAdjustment reports that odds are changed by the bookmaker's risk
+// management, not because of the event.
+func (o *OddsChange) IsRiskAdjustment() bool {
+	return o.OddsChangeReason != nil && *o.OddsChangeReason == OddsChangeReasonRiskAdjustment
+}
+
+// IsBetstop reports that markets are suspended, betstop reason is set.
+func (o *OddsChange) IsBetstop() bool {
+	return o.BetstopReason != nil
+}
+
+// Validate checks that enum values are in the range of documented values.
+// Unknown value usually means that Betradar added new one, so it is reported
+// as notice.
+func (o *OddsChange) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if v := o.BettingStatus; v != nil && !v.Valid() {
+		return Notice("odds change", fmt.Errorf("unknown betting status %d", *v))
+	}
+	if v := o.BetstopReason; v != nil && !v.Valid() {
+		return Notice("odds change", fmt.Errorf("unknown betstop reason %d", *v))
+	}
+	if v := o.OddsChangeReason; v != nil && !v.Valid() {
+		return Notice("odds change", fmt.Errorf("unknown odds change reason %d", *v))
+	}
+	return nil
+}
+
 func (o *OddsChange) EachPlayer(handler func(int)) {
 	if o == nil {
 		return
@@ -40,19 +40,37 @@ func TestOddsChange(t *testing.T) {
 	assert.Equal(t, oc, m.OddsChange)
 }
 
+func TestOddsChangeUnknownEnumUnpack(t *testing.T) {
+	buf := []byte(`<odds_change product="1" event_id="sr:match:1234" timestamp="1234">
+	<odds betting_status="9" betstop_reason="2"/>
+	</odds_change>`)
+	m, err := NewQueueMessage("hi.pre.-.odds_change.1.sr:match.1234.-", buf)
+	assert.EqualError(t, err, "NOTICE uof error op: odds change, inner: unknown betting status 9")
+	// message is still returned
+	assert.NotNil(t, m)
+	assert.Equal(t, BettingStatus(9), *m.OddsChange.BettingStatus)
+	assert.Equal(t, BetstopReasonPossibleRedCard, *m.OddsChange.BetstopReason)
+}
+
 func testOddsChangeUnmarshal(t *testing.T, oc *OddsChange) {
 	assert.Len(t, oc.Markets, 9)
 	assert.Equal(t, 123, oc.EventID)
 	assert.Equal(t, 2, int(oc.Producer))
 	assert.Equal(t, 1234, int(oc.Timestamp))
-	assert.Equal(t, 1, *oc.BettingStatus)
-	assert.Equal(t, 2, *oc.BetstopReason)
+	assert.Equal(t, BettingStatusPossibleGoal, *oc.BettingStatus)
+	assert.Equal(t, BetstopReasonPossibleRedCard, *oc.BetstopReason)
+	assert.True(t, oc.IsBetstop())
+	assert.False(t, oc.IsRiskAdjustment())
+	assert.NoError(t, oc.Validate())
 
 	assert.Equal(t, int(12345), *oc.Markets[0].NextBetstop)
 
 	// market line calculation in unmarshal
 	assert.Equal(t, 0, oc.Markets[4].LineID)
 	assert.Equal(t, 2701050930, oc.Markets[0].LineID)
+	// unsorted specifiers, LineID is hash of the feed string, not canonical form
+	assert.Equal(t, 3674095794, oc.Markets[3].LineID)
+	assert.Equal(t, "game=3|point=1|set=2", oc.Markets[3].LineSpecifiers)
 
 	// outcome with 'normal' id
 	assert.Equal(t, 1, oc.Markets[3].Outcomes[0].ID)
@@ -208,3 +226,22 @@ func TestNilMethodCalls(t *testing.T) {
 	})
 
 }
+
+func TestOutcomeID(t *testing.T) {
+	data := []struct {
+		id       string
+		outcome  int
+		playerID int
+	}{
+		{"1", 1, 0},
+		{"sr:player:1234", 1234, 1234},
+		{"sr:player:1234:1", hash32("sr:player:1234:1"), 1234},
+		{"sr:player:1234:2", hash32("sr:player:1234:2"), 1234},
+		{"pre:outcometext:5", hash32("pre:outcometext:5"), 0},
+		{"pre:playerprops:35432179:608000:1", hash32("pre:playerprops:35432179:608000:1"), 0},
+	}
+	for _, d := range data {
+		assert.Equal(t, d.outcome, toOutcomeID(d.id), d.id)
+		assert.Equal(t, d.playerID, toPlayerID(d.id), d.id)
+	}
+}
@@ -329,6 +329,13 @@ func TestFixture(t *testing.T) {
 	assert.Equal(t, "extended_live_markets_offered", f.ExtraInfo[4].Key)
 	assert.Equal(t, "true", f.ExtraInfo[4].Value)
 
+	assert.True(t, f.ProductInfo.IsInLiveScore)
+	assert.True(t, f.ProductInfo.IsInHostedStatistics)
+	assert.True(t, f.ProductInfo.IsInLiveCenterSoccer)
+	assert.False(t, f.ProductInfo.IsAutoTraded)
+	assert.Equal(t, "platinum", f.CoverageInfo.Level)
+	assert.Len(t, f.CoverageInfo.Includes, 7)
+
 	// test creating uof.Message
 	msgAPI, err := NewAPIMessage(LangEN, MessageTypeFixture, buf)
 	assert.NoError(t, err)
@@ -352,6 +359,74 @@ func TestFixutreWithPlayers(t *testing.T) {
 	assert.Equal(t, "Goldhoff, George", msg.Fixture.Competitors[1].Players[0].Name)
 }
 
+func TestFixtureSections(t *testing.T) {
+	buf, err := ioutil.ReadFile("./testdata/fixture-4.xml")
+	assert.Nil(t, err)
+
+	msg, err := NewAPIMessage(LangEN, MessageTypeFixture, buf)
+	assert.NoError(t, err)
+	f := msg.Fixture
+
+	assert.Equal(t, []ReferenceID{{Name: "BetradarCtrl", Value: "22893617"}, {Name: "aams", Value: "7542"}}, f.ReferenceIDs)
+	assert.Equal(t, []ReferenceID{{Name: "betradar", Value: "7025"}}, f.Home.ReferenceIDs)
+
+	c := f.SportEventConditions
+	assert.Equal(t, 44236, c.Attendance)
+	assert.Equal(t, "bo1", c.MatchMode)
+	assert.Equal(t, Referee{ID: 52586, Name: "Jovanovic, Milorad", Nationality: "Serbia"}, *c.Referee)
+	assert.Equal(t, "good", c.WeatherInfo.Pitch)
+	assert.Equal(t, "cloudy", c.WeatherInfo.WeatherConditions)
+	assert.Equal(t, 8, *c.WeatherInfo.TemperatureCelsius)
+	assert.Equal(t, "light", c.WeatherInfo.Wind)
+	assert.Equal(t, "none", c.WeatherInfo.WindAdvantage)
+
+	assert.Equal(t, DelayedInfo{ID: 3, Description: "Crowd trouble"}, *f.DelayedInfo)
+
+	ci := f.CoverageInfo
+	assert.Equal(t, "gold", ci.Level)
+	assert.True(t, ci.LiveCoverage)
+	assert.Equal(t, "venue", ci.CoveredFrom)
+	assert.Equal(t, []string{"basic_score", "key_events", "detailed_events"}, ci.Includes)
+
+	assert.Len(t, f.ScheduledStartTimeChanges, 2)
+	sc := f.ScheduledStartTimeChanges[0]
+	assert.Equal(t, "2020-02-19T17:55:00Z", sc.OldTime.Format(time.RFC3339))
+	assert.Equal(t, "2020-02-19T20:00:00Z", sc.NewTime.Format(time.RFC3339))
+	assert.Equal(t, "2019-12-20T11:31:05Z", sc.ChangedAt.Format(time.RFC3339))
+
+	pi := f.ProductInfo
+	assert.True(t, pi.IsInLiveScore)
+	assert.False(t, pi.IsInHostedStatistics)
+	assert.False(t, pi.IsInLiveCenterSoccer)
+	assert.True(t, pi.IsAutoTraded)
+	assert.Nil(t, f.Parent)
+
+	// survives json round trip
+	m := &Message{}
+	assert.NoError(t, m.Unmarshal(msg.Marshal()))
+	assert.Equal(t, *f, *m.Fixture)
+}
+
+func TestFixtureParentStage(t *testing.T) {
+	buf, err := ioutil.ReadFile("./testdata/fixture-5.xml")
+	assert.Nil(t, err)
+
+	msg, err := NewAPIMessage(LangEN, MessageTypeFixture, buf)
+	assert.NoError(t, err)
+	f := msg.Fixture
+	assert.Equal(t, "Australian Grand Prix 2020 - Race", f.Name)
+	p := f.Parent
+	assert.NotNil(t, p)
+	assert.Equal(t, URN("sr:stage:547403"), p.URN)
+	assert.Equal(t, "Australian Grand Prix 2020", p.Name)
+	assert.Equal(t, "parent", p.Type)
+	assert.Equal(t, "2020-03-13T01:00:00Z", p.Scheduled.Format(time.RFC3339))
+	assert.Equal(t, p.URN.EventID(), p.ID)
+	assert.True(t, f.ProductInfo.IsInHostedStatistics)
+	assert.Nil(t, f.CoverageInfo)
+	assert.Nil(t, f.SportEventConditions)
+}
+
 func TestFixtureNoTournament(t *testing.T) {
 	buf, err := ioutil.ReadFile("./testdata/fixture-3.xml")
 	assert.Nil(t, err)
@@ -362,6 +437,23 @@ func TestFixtureNoTournament(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBookmakerDetails(t *testing.T) {
+	buf, err := ioutil.ReadFile("./testdata/bookmaker_details.xml")
+	assert.Nil(t, err)
+
+	bd := &BookmakerDetails{}
+	err = xml.Unmarshal(buf, bd)
+	assert.Nil(t, err)
+	assert.Equal(t, 1234, bd.BookmakerID)
+	assert.Equal(t, "/unifiedfeed/1234", bd.VirtualHost)
+	assert.Equal(t, "OK", bd.ResponseCode)
+	assert.Equal(t, "2025-07-26T17:44:24Z", bd.ExpireAt.Format(time.RFC3339))
+
+	bd.ExpireAt = time.Now().Add(time.Hour)
+	assert.True(t, bd.ExpiresWithin(2*time.Hour))
+	assert.False(t, bd.ExpiresWithin(time.Minute))
+}
+
 func TestBetSettlementToResult(t *testing.T) {
 	data := []struct {
 		result         int
@@ -1,24 +1,66 @@
 package pipe
 
+//go:generate go run ../cmd/marketgroups -o market_groups.go
+
 import (
 	"encoding/json"
 	"math"
 	"sort"
+	"time"
 
 	"github.com/minus5/go-uof-sdk"
 )
 
-func marketGroups() map[string][]int {
-	var marketGroups map[string][]int
-	err := json.Unmarshal([]byte(marketGroupsJSON), &marketGroups)
+// open lines of the event are forgotten if there is no odds change for the
+// event in eventLinesTTL
+const eventLinesTTL = 24 * time.Hour
+
+// groups of each market id from the embedded market descriptions snapshot
+func embeddedGroups() map[int][]string {
+	var groups map[int][]string
+	err := json.Unmarshal([]byte(marketGroupsJSON), &groups)
 	if err != nil {
 		panic(err)
 	}
+	return groups
+}
+
+// marketGroups returns market ids in each group
+func marketGroups() map[string][]int {
+	return groupMarkets(embeddedGroups())
+}
+
+func groupMarkets(groups map[int][]string) map[string][]int {
+	marketGroups := make(map[string][]int)
+	for id, gs := range groups {
+		for _, g := range gs {
+			marketGroups[g] = append(marketGroups[g], id)
+		}
+	}
 	return marketGroups
 }
 
 type betStop struct {
+	// groups of each market id, language independent
+	groups       map[int][]string
 	marketGroups map[string][]int
+	// open market lines of each event
+	lines   map[int]*eventLines
+	sweptAt time.Time
+}
+
+type eventLines struct {
+	open      map[uof.MarketLine]struct{}
+	updatedAt time.Time
+}
+
+func newBetStop() *betStop {
+	groups := embeddedGroups()
+	return &betStop{
+		groups:       groups,
+		marketGroups: groupMarkets(groups),
+		lines:        make(map[int]*eventLines),
+	}
 }
 
 // BetStop enriches bet stop messages with the list of the marketIDs which
@@ -26,11 +68,10 @@ type betStop struct {
 // event messages we have only market ids. To allow client not to need to know
 // the list of all markets to make connection between groups and ids we are here
 // adding to the bet stop message those ids.
+// Lines of those markets currently open on the event (active in the last odds
+// change) are also added.
 func BetStop() InnerStage {
-	b := betStop{
-		marketGroups: marketGroups(),
-	}
-	return Stage(b.loop)
+	return Stage(newBetStop().loop)
 }
 
 func (b *betStop) loop(in <-chan *uof.Message, out chan<- *uof.Message, errc chan<- error) {
@@ -38,6 +79,10 @@ func (b *betStop) loop(in <-chan *uof.Message, out chan<- *uof.Message, errc cha
 		switch m.Type {
 		case uof.MessageTypeBetStop:
 			b.enrich(m)
+		case uof.MessageTypeOddsChange:
+			b.oddsChange(m)
+		case uof.MessageTypeBetSettlement:
+			b.betSettlement(m)
 		case uof.MessageTypeMarkets:
 			b.refresh(m)
 		}
@@ -45,16 +90,110 @@ func (b *betStop) loop(in <-chan *uof.Message, out chan<- *uof.Message, errc cha
 	}
 }
 
+// refresh follows changes in the market descriptions. Groups are the same in
+// each language so messages in any language are used.
 func (b *betStop) refresh(m *uof.Message) {
-	if m.Lang != uof.LangEN || m.Markets == nil {
+	switch {
+	case m.MarketsDiff != nil:
+		for _, md := range m.MarketsDiff.Removed {
+			delete(b.groups, md.ID)
+		}
+		for _, md := range m.Markets {
+			b.groups[md.ID] = md.Groups
+		}
+	case m.MarketVariant != "":
+		// all variants have the same groups as the market
+		added := false
+		for _, md := range m.Markets {
+			if _, ok := b.groups[md.ID]; !ok {
+				b.groups[md.ID] = md.Groups
+				added = true
+			}
+		}
+		if !added {
+			return
+		}
+	case m.MarketsAll:
+		b.groups = make(map[int][]string)
+		for _, md := range m.Markets {
+			b.groups[md.ID] = md.Groups
+		}
+	case len(m.Markets) > 0:
+		// partial descriptions are merged
+		for _, md := range m.Markets {
+			b.groups[md.ID] = md.Groups
+		}
+	default:
+		return
+	}
+	b.marketGroups = groupMarkets(b.groups)
+}
+
+// oddsChange tracks open lines of the event. Active market line is open, any
+// other status closes it.
+func (b *betStop) oddsChange(m *uof.Message) {
+	oc := m.OddsChange
+	if oc == nil {
 		return
 	}
-	b.marketGroups = m.Markets.Groups()
+	now := time.Now()
+	el, ok := b.lines[oc.EventID]
+	if !ok {
+		el = &eventLines{open: make(map[uof.MarketLine]struct{})}
+		b.lines[oc.EventID] = el
+	}
+	el.updatedAt = now
+	for _, mk := range oc.Markets {
+		l := uof.MarketLine{MarketID: mk.ID, LineID: mk.LineID}
+		if mk.Status == uof.MarketStatusActive {
+			el.open[l] = struct{}{}
+			continue
+		}
+		delete(el.open, l)
+	}
+	b.sweep(now)
+}
+
+// betSettlement closes settled lines
+func (b *betStop) betSettlement(m *uof.Message) {
+	bs := m.BetSettlement
+	if bs == nil {
+		return
+	}
+	el, ok := b.lines[bs.EventID]
+	if !ok {
+		return
+	}
+	for _, mk := range bs.Markets {
+		delete(el.open, uof.MarketLine{MarketID: mk.ID, LineID: mk.LineID})
+	}
+	if len(el.open) == 0 {
+		delete(b.lines, bs.EventID)
+	}
+}
+
+// sweep removes events without odds change in eventLinesTTL, at most once an
+// hour
+func (b *betStop) sweep(now time.Time) {
+	if now.Sub(b.sweptAt) < time.Hour {
+		return
+	}
+	b.sweptAt = now
+	for id, el := range b.lines {
+		if now.Sub(el.updatedAt) >= eventLinesTTL {
+			delete(b.lines, id)
+		}
+	}
 }
 
 func (b *betStop) enrich(m *uof.Message) {
 	bs := m.BetStop
-	if bs == nil || bs.Groups == nil {
+	if bs == nil {
+		return
+	}
+	if bs.Groups == nil {
+		// "all" group is removed while parsing, all open lines are stopped
+		bs.Lines = b.openLines(bs.EventID, nil, true)
 		return
 	}
 	var marketIDs []int
@@ -64,6 +203,32 @@ func (b *betStop) enrich(m *uof.Message) {
 		}
 	}
 	bs.MarketIDs = dedup(marketIDs)
+	bs.Lines = b.openLines(bs.EventID, bs.MarketIDs, false)
+}
+
+// openLines of the event for the markets, or all open lines
+func (b *betStop) openLines(eventID int, marketIDs []int, all bool) []uof.MarketLine {
+	el, ok := b.lines[eventID]
+	if !ok {
+		return nil
+	}
+	ids := make(map[int]bool, len(marketIDs))
+	for _, id := range marketIDs {
+		ids[id] = true
+	}
+	var lines []uof.MarketLine
+	for l := range el.open {
+		if all || ids[l.MarketID] {
+			lines = append(lines, l)
+		}
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].MarketID != lines[j].MarketID {
+			return lines[i].MarketID < lines[j].MarketID
+		}
+		return lines[i].LineID < lines[j].LineID
+	})
+	return lines
 }
 
 func dedup(in []int) []int {
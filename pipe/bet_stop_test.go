@@ -2,15 +2,14 @@ package pipe
 
 import (
 	"testing"
+	"time"
 
 	"github.com/minus5/go-uof-sdk"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestBetStop(t *testing.T) {
-	b := betStop{
-		marketGroups: marketGroups(),
-	}
+	b := newBetStop()
 	m := &uof.Message{
 		Header: uof.Header{
 			Type: uof.MessageTypeBetStop,
@@ -39,3 +38,125 @@ func TestDedup(t *testing.T) {
 	b := dedup(a)
 	assert.Equal(t, []int{1, 2, 3, 4, 5}, b)
 }
+
+func TestBetStopRefresh(t *testing.T) {
+	b := newBetStop()
+	enrich := func(groups ...string) []int {
+		m := &uof.Message{
+			Header: uof.Header{Type: uof.MessageTypeBetStop},
+			Body:   uof.Body{BetStop: &uof.BetStop{Groups: groups}},
+		}
+		b.enrich(m)
+		return m.BetStop.MarketIDs
+	}
+
+	// variant market of the known market is ignored
+	b.refresh(uof.NewMarketVariantMessage(uof.LangEN, "sr:exact_goals:4+", uof.MarketDescriptions{{ID: 1, Groups: []string{"score"}}}, 0))
+	assert.Len(t, enrich("regular_play"), 217)
+	// unknown is added to the embedded groups
+	b.refresh(uof.NewMarketVariantMessage(uof.LangEN, "sr:exact_goals:4+", uof.MarketDescriptions{{ID: 2000, Groups: []string{"regular_play"}}}, 0))
+	assert.Len(t, enrich("regular_play"), 218)
+
+	// any language replaces all markets
+	b.refresh(uof.NewMarketsMessage(uof.LangDE, uof.MarketDescriptions{{ID: 1, Groups: []string{"score"}}, {ID: 2}}, 0))
+	assert.Equal(t, []int{}, enrich("regular_play"))
+	assert.Equal(t, []int{1}, enrich("score"))
+
+	b.refresh(uof.NewMarketsMessage(uof.LangEN, uof.MarketDescriptions{
+		{ID: 1, Groups: []string{"score", "regular_play"}},
+		{ID: 18, Groups: []string{"score", "regular_play"}},
+		{ID: 16, Groups: []string{"regular_play"}},
+	}, 0))
+	assert.Equal(t, []int{1, 16, 18}, enrich("regular_play"))
+	assert.Equal(t, []int{1, 18}, enrich("score"))
+
+	b.refresh(uof.NewMarketVariantMessage(uof.LangEN, "sr:point_range:76+", uof.MarketDescriptions{{ID: 21, Groups: []string{"score"}}}, 0))
+	assert.Equal(t, []int{1, 18, 21}, enrich("score"))
+
+	// full descriptions with the single market replace all markets
+	b.refresh(uof.NewMarketsMessage(uof.LangEN, uof.MarketDescriptions{{ID: 21, Groups: []string{"score"}}}, 0))
+	assert.Equal(t, []int{21}, enrich("score"))
+	b.refresh(uof.NewMarketsMessage(uof.LangEN, uof.MarketDescriptions{
+		{ID: 1, Groups: []string{"score", "regular_play"}},
+		{ID: 18, Groups: []string{"score", "regular_play"}},
+		{ID: 16, Groups: []string{"regular_play"}},
+		{ID: 21, Groups: []string{"score"}},
+	}, 0))
+
+	b.refresh(uof.NewMarketsDiffMessage(uof.LangEN, uof.MarketsDiff{
+		Added:   uof.MarketDescriptions{{ID: 10, Groups: []string{"score"}}},
+		Changed: uof.MarketDescriptions{{ID: 1, Groups: []string{"regular_play"}}},
+		Removed: uof.MarketDescriptions{{ID: 18}},
+	}, 0))
+	assert.Equal(t, []int{10, 21}, enrich("score"))
+	assert.Equal(t, []int{1, 16}, enrich("regular_play"))
+
+	// partial markets message is merged into the full set
+	pm := &uof.Message{
+		Header: uof.Header{Type: uof.MessageTypeMarkets, Lang: uof.LangEN},
+		Body:   uof.Body{Markets: uof.MarketDescriptions{{ID: 30, Groups: []string{"score"}}}},
+	}
+	b.refresh(pm)
+	assert.Equal(t, []int{10, 21, 30}, enrich("score"))
+	assert.Equal(t, []int{1, 16}, enrich("regular_play"))
+}
+
+func TestMarketGroupsEmbedded(t *testing.T) {
+	groups := embeddedGroups()
+	assert.Equal(t, []string{"regular_play", "score"}, groups[1])
+	mg := marketGroups()
+	assert.Len(t, mg["regular_play"], 217)
+	assert.Len(t, mg["corners"], 46)
+}
+
+func TestBetStopLines(t *testing.T) {
+	b := newBetStop()
+	oddsChange := func(eventID int, ms ...uof.Market) {
+		b.oddsChange(&uof.Message{
+			Header: uof.Header{Type: uof.MessageTypeOddsChange},
+			Body:   uof.Body{OddsChange: &uof.OddsChange{EventID: eventID, Markets: ms}},
+		})
+	}
+	betStop := func(eventID int, groups ...string) *uof.BetStop {
+		m := &uof.Message{
+			Header: uof.Header{Type: uof.MessageTypeBetStop},
+			Body:   uof.Body{BetStop: &uof.BetStop{EventID: eventID, Groups: groups}},
+		}
+		b.enrich(m)
+		return m.BetStop
+	}
+	active := uof.MarketStatusActive
+
+	oddsChange(1,
+		uof.Market{ID: 1, Status: active},
+		uof.Market{ID: 18, LineID: 2, Status: active},
+		uof.Market{ID: 18, LineID: 1, Status: active},
+		uof.Market{ID: 18, LineID: 3, Status: uof.MarketStatusSuspended},
+		uof.Market{ID: 102, Status: active}, // 15_min
+	)
+	oddsChange(2, uof.Market{ID: 1, Status: active})
+
+	bs := betStop(1, "regular_play")
+	assert.Equal(t, []uof.MarketLine{{MarketID: 1}, {MarketID: 18, LineID: 1}, {MarketID: 18, LineID: 2}}, bs.Lines)
+	assert.Len(t, betStop(1).Lines, 4)
+	assert.Nil(t, betStop(3, "regular_play").Lines)
+
+	// deactivated and settled lines are closed
+	oddsChange(1, uof.Market{ID: 18, LineID: 2, Status: uof.MarketStatusInactive})
+	b.betSettlement(&uof.Message{
+		Header: uof.Header{Type: uof.MessageTypeBetSettlement},
+		Body: uof.Body{BetSettlement: &uof.BetSettlement{EventID: 1, Markets: []uof.BetSettlementMarket{
+			{ID: 1},
+		}}},
+	})
+	bs = betStop(1, "regular_play")
+	assert.Equal(t, []uof.MarketLine{{MarketID: 18, LineID: 1}}, bs.Lines)
+
+	// stale events are removed
+	b.lines[2].updatedAt = time.Now().Add(-eventLinesTTL)
+	b.sweep(time.Now())
+	assert.Len(t, b.lines, 2)
+	b.sweptAt = time.Time{}
+	b.sweep(time.Now())
+	assert.Len(t, b.lines, 1)
+}
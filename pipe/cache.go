@@ -0,0 +1,188 @@
+package pipe
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minus5/go-uof-sdk"
+)
+
+// DiskCache keeps lexicon messages (markets, fixtures, players) on disk for
+// warm restarts. Uses the same /state/... layout as FileStore but keeps only
+// the latest message for each entry.
+// Message is fresh if it is requested less than ttl ago.
+// Nil DiskCache is valid and caches nothing.
+type DiskCache struct {
+	root string
+	ttl  time.Duration
+}
+
+func NewDiskCache(root string, ttl time.Duration) *DiskCache {
+	return &DiskCache{root: root, ttl: ttl}
+}
+
+// Markets returns fresh cached all markets message for the language.
+func (c *DiskCache) Markets(lang uof.Lang) *uof.Message {
+	m := c.load(marketsDir(lang))
+	if m != nil {
+		// messages saved before the MarketsAll flag
+		m.MarketsAll = true
+	}
+	return m
+}
+
+// MarketVariant returns fresh cached variant market message.
+func (c *DiskCache) MarketVariant(lang uof.Lang, marketID int, variant string) *uof.Message {
+	return c.load(marketVariantDir(lang, marketID, uof.Hash(variant)))
+}
+
+// Fixture returns fresh cached fixture message.
+func (c *DiskCache) Fixture(lang uof.Lang, eventID int) *uof.Message {
+	return c.load(fixtureDir(lang, eventID))
+}
+
+// Fixtures returns all fresh cached fixtures for the language.
+func (c *DiskCache) Fixtures(lang uof.Lang) []*uof.Message {
+	if c == nil {
+		return nil
+	}
+	parent := path.Dir(fixtureDir(lang, 0))
+	fis, err := ioutil.ReadDir(c.root + parent)
+	if err != nil {
+		return nil
+	}
+	var ms []*uof.Message
+	for _, fi := range fis {
+		if !fi.IsDir() {
+			continue
+		}
+		if m := c.load(parent + "/" + fi.Name()); m != nil {
+			ms = append(ms, m)
+		}
+	}
+	return ms
+}
+
+// Player returns fresh cached player message.
+func (c *DiskCache) Player(lang uof.Lang, playerID int) *uof.Message {
+	return c.load(playerDir(lang, playerID))
+}
+
+// Preloaded reports whether fixtures preload for the language is finished
+// less than ttl ago.
+func (c *DiskCache) Preloaded(lang uof.Lang) bool {
+	if c == nil {
+		return false
+	}
+	fn, ok := c.latest(preloadDir(lang))
+	if !ok {
+		return false
+	}
+	ts, err := strconv.Atoi(strings.TrimSpace(path.Base(fn)))
+	if err != nil {
+		return false
+	}
+	return c.fresh(ts)
+}
+
+// SetPreloaded marks fixtures preload for the language finished.
+func (c *DiskCache) SetPreloaded(lang uof.Lang) error {
+	if c == nil {
+		return nil
+	}
+	dir := preloadDir(lang)
+	fn := fmt.Sprintf("%s/%13d", dir, uof.CurrentTimestamp())
+	if err := save(c.root+fn, nil); err != nil {
+		return err
+	}
+	return c.prune(dir, fn)
+}
+
+// Save stores lexicon message and removes older messages for the same entry.
+func (c *DiskCache) Save(m *uof.Message) error {
+	if c == nil || m.Type.Kind() != uof.MessageKindLexicon {
+		return nil
+	}
+	if m.Type == uof.MessageTypeMarkets && (len(m.Markets) == 0 || m.MarketsDiff != nil) {
+		// only complete descriptions are cached
+		return nil
+	}
+	fn := filename(m)
+	if err := save(c.root+fn, m.Marshal()); err != nil {
+		return err
+	}
+	return c.prune(path.Dir(fn), fn)
+}
+
+func (c *DiskCache) fresh(requestedAt int) bool {
+	return uof.CurrentTimestamp()-requestedAt < int(c.ttl/time.Millisecond)
+}
+
+// load latest message from the dir if it is fresh
+func (c *DiskCache) load(dir string) *uof.Message {
+	if c == nil {
+		return nil
+	}
+	fn, ok := c.latest(dir)
+	if !ok {
+		return nil
+	}
+	buf, err := ioutil.ReadFile(c.root + fn)
+	if err != nil {
+		return nil
+	}
+	m := &uof.Message{}
+	if err := m.Unmarshal(buf); err != nil {
+		return nil
+	}
+	if !c.fresh(m.RequestedAt) {
+		return nil
+	}
+	return m
+}
+
+// latest file name in the dir, files are named by timestamp
+func (c *DiskCache) latest(dir string) (string, bool) {
+	names, err := c.names(dir)
+	if err != nil || len(names) == 0 {
+		return "", false
+	}
+	return dir + "/" + names[len(names)-1], true
+}
+
+func (c *DiskCache) names(dir string) ([]string, error) {
+	fis, err := ioutil.ReadDir(c.root + dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, fi := range fis {
+		if !fi.IsDir() {
+			names = append(names, fi.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// prune removes all files in the dir except keep
+func (c *DiskCache) prune(dir, keep string) error {
+	names, err := c.names(dir)
+	if err != nil {
+		return err
+	}
+	for _, n := range names {
+		if fn := dir + "/" + n; fn != keep {
+			if err := os.Remove(c.root + fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package pipe
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minus5/go-uof-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskCache(t *testing.T) {
+	root, err := ioutil.TempDir("", "uof-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	c := NewDiskCache(root, time.Hour)
+	now := uof.CurrentTimestamp()
+
+	ms := uof.MarketDescriptions{{ID: 1, Name: "1x2"}, {ID: 18, Name: "Total"}}
+	assert.Nil(t, c.Markets(uof.LangEN))
+	assert.NoError(t, c.Save(uof.NewMarketsMessage(uof.LangEN, ms, now-1000)))
+	assert.NoError(t, c.Save(uof.NewMarketsMessage(uof.LangEN, ms, now)))
+	m := c.Markets(uof.LangEN)
+	assert.NotNil(t, m)
+	assert.Equal(t, now, m.RequestedAt)
+	assert.Equal(t, ms, m.Markets)
+	// older message is removed
+	fis, err := ioutil.ReadDir(root + marketsDir(uof.LangEN))
+	assert.NoError(t, err)
+	assert.Len(t, fis, 1)
+	assert.Nil(t, c.Markets(uof.LangDE))
+
+	vm := uof.MarketDescriptions{{ID: 145, Variant: "sr:point_range:76+", VariantID: uof.Hash("sr:point_range:76+")}}
+	assert.NoError(t, c.Save(uof.NewMarketVariantMessage(uof.LangEN, "sr:point_range:76+", vm, now)))
+	assert.NotNil(t, c.MarketVariant(uof.LangEN, 145, "sr:point_range:76+"))
+	assert.Nil(t, c.MarketVariant(uof.LangEN, 145, "sr:point_range:6+"))
+
+	assert.NoError(t, c.Save(uof.NewPlayerMessage(uof.LangEN, &uof.Player{ID: 1, Name: "Kane, Harry"}, now)))
+	assert.Equal(t, "Kane, Harry", c.Player(uof.LangEN, 1).Player.Name)
+
+	assert.NoError(t, c.Save(uof.NewFixtureMessage(uof.LangEN, uof.Fixture{ID: 1, URN: "sr:match:1"}, now)))
+	assert.NoError(t, c.Save(uof.NewFixtureMessage(uof.LangEN, uof.Fixture{ID: 2, URN: "sr:match:2"}, now)))
+	// stale
+	assert.NoError(t, c.Save(uof.NewFixtureMessage(uof.LangEN, uof.Fixture{ID: 3, URN: "sr:match:3"}, now-2*3600*1000)))
+	assert.NotNil(t, c.Fixture(uof.LangEN, 1))
+	assert.Nil(t, c.Fixture(uof.LangEN, 3))
+	assert.Len(t, c.Fixtures(uof.LangEN), 2)
+
+	assert.False(t, c.Preloaded(uof.LangEN))
+	assert.NoError(t, c.SetPreloaded(uof.LangEN))
+	assert.True(t, c.Preloaded(uof.LangEN))
+	assert.False(t, c.Preloaded(uof.LangDE))
+
+	// nil cache
+	var nc *DiskCache
+	assert.Nil(t, nc.Markets(uof.LangEN))
+	assert.Nil(t, nc.Fixtures(uof.LangEN))
+	assert.False(t, nc.Preloaded(uof.LangEN))
+	assert.NoError(t, nc.Save(uof.NewMarketsMessage(uof.LangEN, ms, now)))
+}
+
+type marketsCountingAPI struct {
+	calls int
+}
+
+func (a *marketsCountingAPI) Markets(lang uof.Lang) (uof.MarketDescriptions, error) {
+	a.calls++
+	return uof.MarketDescriptions{{ID: 1, Name: "1x2"}, {ID: 18, Name: "Total"}}, nil
+}
+
+func (a *marketsCountingAPI) MarketVariant(lang uof.Lang, marketID int, variant string) (uof.MarketDescriptions, error) {
+	return nil, nil
+}
+
+func TestMarketsPipeDiskCache(t *testing.T) {
+	root, err := ioutil.TempDir("", "uof-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+	c := NewDiskCache(root, time.Hour)
+
+	run := func(a marketsAPI) []*uof.Message {
+		in := make(chan *uof.Message)
+		out, _ := Markets(a, []uof.Lang{uof.LangEN}, WithDiskCache(c))(in)
+		close(in)
+		var ms []*uof.Message
+		for m := range out {
+			ms = append(ms, m)
+		}
+		return ms
+	}
+
+	a := &marketsCountingAPI{}
+	ms := run(a)
+	assert.Len(t, ms, 1)
+	assert.Equal(t, 1, a.calls)
+
+	// second start loads markets from the cache
+	ms = run(a)
+	assert.Len(t, ms, 1)
+	assert.Len(t, ms[0].Markets, 2)
+	assert.Equal(t, 1, a.calls)
+}
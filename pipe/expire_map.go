@@ -0,0 +1,208 @@
+package pipe
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CachePolicy of the stage in memory cache of the requested api resources.
+// Resource is not requested again while it is fresh in the cache.
+// Stage defaults are TTL of 24 hours for markets, 1 minute for fixtures and 1
+// hour for players; MaxSize 100000 for each. Fixture stage applies MaxSize
+// also to the seen events and to the previous fixture versions kept for diff.
+type CachePolicy struct {
+	// how long entry is fresh after insert
+	TTL time.Duration
+	// max number of entries, least recently used are evicted first; zero is
+	// default 100000, negative is unlimited
+	MaxSize int
+	// optional, filled by the stage
+	Metrics *CacheMetrics
+}
+
+// default max number of entries in each stage cache
+const defaultCacheMaxSize = 100000
+
+// CacheMetrics counters of the stage cache. Safe for concurrent use.
+type CacheMetrics struct {
+	hits      int64
+	misses    int64
+	evictions int64
+	size      int64
+}
+
+func (m *CacheMetrics) Hits() int64      { return atomic.LoadInt64(&m.hits) }
+func (m *CacheMetrics) Misses() int64    { return atomic.LoadInt64(&m.misses) }
+func (m *CacheMetrics) Evictions() int64 { return atomic.LoadInt64(&m.evictions) }
+func (m *CacheMetrics) Size() int64      { return atomic.LoadInt64(&m.size) }
+
+// expireMap is TTL + LRU map of keys to optional values. Expired entries are removed on insert,
+// at most once per cleanup interval, so there is no background goroutine to
+// stop. When size limit is reached least recently used are evicted.
+type expireMap struct {
+	ttl         time.Duration
+	maxSize     int
+	interval    time.Duration
+	nextCleanup time.Time
+	items       map[int]*list.Element
+	lru         *list.List // front is most recently used
+	metrics     *CacheMetrics
+	sync.Mutex
+}
+
+type expireEntry struct {
+	key        int
+	value      interface{}
+	insertedAt time.Time
+}
+
+func newExpireMap(ttl time.Duration) *expireMap {
+	return newExpireMapWithPolicy(CachePolicy{TTL: ttl})
+}
+
+func newExpireMapWithPolicy(p CachePolicy) *expireMap {
+	if p.MaxSize == 0 {
+		p.MaxSize = defaultCacheMaxSize
+	}
+	interval := cleanupInterval(p.TTL)
+	em := &expireMap{
+		ttl:         p.TTL,
+		maxSize:     p.MaxSize,
+		interval:    interval,
+		nextCleanup: time.Now().Add(interval),
+		items:       make(map[int]*list.Element),
+		lru:         list.New(),
+		metrics:     p.Metrics,
+	}
+	if em.metrics == nil {
+		em.metrics = &CacheMetrics{}
+	}
+	return em
+}
+
+func cleanupInterval(ttl time.Duration) time.Duration {
+	switch {
+	case ttl < time.Second:
+		return time.Second
+	case ttl > time.Minute:
+		return time.Minute
+	}
+	return ttl
+}
+
+func (em *expireMap) cleanup() {
+	em.Lock()
+	defer em.Unlock()
+	em.removeExpired(time.Now())
+}
+
+func (em *expireMap) removeExpired(now time.Time) {
+	em.nextCleanup = now.Add(em.interval)
+	for k, e := range em.items {
+		if em.expired(e.Value.(*expireEntry), now) {
+			em.delete(k, e)
+			atomic.AddInt64(&em.metrics.evictions, 1)
+		}
+	}
+}
+
+func (em *expireMap) expired(e *expireEntry, now time.Time) bool {
+	return now.Sub(e.insertedAt) >= em.ttl
+}
+
+func (em *expireMap) fresh(k int) bool {
+	_, ok := em.get(k)
+	return ok
+}
+
+// get value of the fresh entry
+func (em *expireMap) get(k int) (interface{}, bool) {
+	em.Lock()
+	defer em.Unlock()
+
+	e, ok := em.items[k]
+	if !ok {
+		atomic.AddInt64(&em.metrics.misses, 1)
+		return nil, false
+	}
+	ee := e.Value.(*expireEntry)
+	if em.expired(ee, time.Now()) {
+		em.delete(k, e)
+		atomic.AddInt64(&em.metrics.evictions, 1)
+		atomic.AddInt64(&em.metrics.misses, 1)
+		return nil, false
+	}
+	em.lru.MoveToFront(e)
+	atomic.AddInt64(&em.metrics.hits, 1)
+	return ee.value, true
+}
+
+func (em *expireMap) insert(key int) {
+	em.set(key, nil)
+}
+
+// set value of the key, entry is fresh for ttl from now
+func (em *expireMap) set(key int, value interface{}) {
+	em.Lock()
+	defer em.Unlock()
+
+	now := time.Now()
+	if now.After(em.nextCleanup) {
+		em.removeExpired(now)
+	}
+	if e, ok := em.items[key]; ok {
+		ee := e.Value.(*expireEntry)
+		ee.value, ee.insertedAt = value, now
+		em.lru.MoveToFront(e)
+		return
+	}
+	em.items[key] = em.lru.PushFront(&expireEntry{key: key, value: value, insertedAt: now})
+	atomic.AddInt64(&em.metrics.size, 1)
+
+	for em.maxSize > 0 && len(em.items) > em.maxSize {
+		e := em.lru.Back()
+		em.delete(e.Value.(*expireEntry).key, e)
+		atomic.AddInt64(&em.metrics.evictions, 1)
+	}
+}
+
+func (em *expireMap) remove(key int) {
+	em.Lock()
+	defer em.Unlock()
+
+	if e, ok := em.items[key]; ok {
+		em.delete(key, e)
+	}
+}
+
+func (em *expireMap) delete(key int, e *list.Element) {
+	em.lru.Remove(e)
+	delete(em.items, key)
+	atomic.AddInt64(&em.metrics.size, -1)
+}
+
+// Cache is in memory TTL + LRU cache of the values with the CachePolicy
+// limits. Used by the lexicon store. Safe for concurrent use.
+type Cache struct {
+	em *expireMap
+}
+
+// NewCache with the policy. Zero TTL is 24 hours.
+func NewCache(p CachePolicy) *Cache {
+	if p.TTL == 0 {
+		p.TTL = 24 * time.Hour
+	}
+	return &Cache{em: newExpireMapWithPolicy(p)}
+}
+
+// Get fresh value of the key.
+func (c *Cache) Get(key int) (interface{}, bool) {
+	return c.em.get(key)
+}
+
+// Set value of the key, it is fresh for the policy TTL.
+func (c *Cache) Set(key int, value interface{}) {
+	c.em.set(key, value)
+}
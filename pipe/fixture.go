@@ -1,6 +1,7 @@
 package pipe
 
 import (
+	"strings"
 	"sync"
 	"time"
 
@@ -9,30 +10,60 @@ import (
 
 type fixtureAPI interface {
 	Fixture(lang uof.Lang, eventURN uof.URN) ([]byte, error)
+	DrawSummary(lang uof.Lang, eventURN uof.URN) ([]byte, error)
 	Fixtures(lang uof.Lang, to time.Time) (<-chan uof.Fixture, <-chan error)
 }
 
+// how long event stays seen; messages of the seen event don't trigger fixture
+// request
+const seenEventTTL = 24 * time.Hour
+
+// how long previous fixture version, and parent of the race, is kept for
+// diffing
+const prevFixtureTTL = 7 * 24 * time.Hour
+
 type fixture struct {
 	api       fixtureAPI
 	languages []uof.Lang // suported languages
 	em        *expireMap
+	seen      *expireMap // events for which fixture is requested
+	hold      bool
+	held      map[int]*heldEvent
+	prev      *expireMap // previous *uof.Fixture version by event and language
+	parents   *expireMap // parent stage uof.URN of the race event
 	errc      chan<- error
 	out       chan<- *uof.Message
 	preloadTo time.Time
 	subProcs  *sync.WaitGroup
 	rateLimit chan struct{}
+	cache     *DiskCache
 	sync.Mutex
 }
 
-func Fixture(api fixtureAPI, languages []uof.Lang, preloadTo time.Time) InnerStage {
+// messages of the event held until fixture is received in all languages
+type heldEvent struct {
+	pending int
+	msgs    []*uof.Message
+	// messages are being sent by release
+	releasing bool
+}
+
+func Fixture(api fixtureAPI, languages []uof.Lang, preloadTo time.Time, opts ...Option) InnerStage {
+	o := newOptions(time.Minute, opts)
 	f := &fixture{
 		api:       api,
 		languages: languages,
-		em:        newExpireMap(time.Minute),
+		em:        newExpireMapWithPolicy(o.cachePolicy),
+		seen:      newExpireMapWithPolicy(CachePolicy{TTL: seenEventTTL, MaxSize: o.cachePolicy.MaxSize}),
+		hold:      o.holdUntilFixture,
+		held:      make(map[int]*heldEvent),
+		prev:      newExpireMapWithPolicy(CachePolicy{TTL: prevFixtureTTL, MaxSize: o.cachePolicy.MaxSize}),
+		parents:   newExpireMapWithPolicy(CachePolicy{TTL: prevFixtureTTL, MaxSize: o.cachePolicy.MaxSize}),
 		//requests:  make(map[string]time.Time),
 		subProcs:  &sync.WaitGroup{},
 		rateLimit: make(chan struct{}, ConcurentAPICallsLimit),
 		preloadTo: preloadTo,
+		cache:     o.cache,
 	}
 	return StageWithSubProcessesSync(f.loop)
 }
@@ -47,28 +78,158 @@ func Fixture(api fixtureAPI, languages []uof.Lang, preloadTo time.Time) InnerSta
 func (f *fixture) loop(in <-chan *uof.Message, out chan<- *uof.Message, errc chan<- error) *sync.WaitGroup {
 	f.errc, f.out = errc, out
 
-	for _, u := range f.preloadLoop(in) {
-		f.getFixture(u, uof.CurrentTimestamp(), true)
+	for _, r := range f.preloadLoop(in) {
+		if r.refresh || f.unseen(r.eventURN) {
+			r.receivedAt = uof.CurrentTimestamp()
+			f.getFixture(r)
+		}
 	}
 	for m := range in {
-		out <- m
-		if u := f.eventURN(m); u != uof.NoURN {
-			f.getFixture(u, m.ReceivedAt, false)
+		r, ok := f.request(m)
+		if !ok {
+			out <- m
+			continue
+		}
+		unseen := f.unseen(r.eventURN)
+		if unseen {
+			f.holdEvent(r.eventURN)
+			r.held = true
+		}
+		f.send(m)
+		if r.refresh || unseen {
+			f.getFixture(r)
 		}
 	}
 
 	return f.subProcs
 }
 
-func (f *fixture) eventURN(m *uof.Message) uof.URN {
-	if m.Type != uof.MessageTypeFixtureChange || m.FixtureChange == nil {
-		return uof.NoURN
+// fixtureRequest of the event fixture in each language
+type fixtureRequest struct {
+	eventURN   uof.URN
+	receivedAt int
+	// skip fresh fixtures check and disk cache
+	refresh bool
+	// fixture change which triggered request, added to the fixture diff
+	change *uof.FixtureChange
+	// release held event messages when done
+	held bool
+	// requested as the parent of the race, its stages are not followed
+	parent bool
+}
+
+// request for the event message fixture. Fixture is refreshed on fixture
+// change, and draw on settlement to get the draw result.
+func (f *fixture) request(m *uof.Message) (fixtureRequest, bool) {
+	if m.Type == uof.MessageTypeFixtureChange && m.FixtureChange != nil {
+		return fixtureRequest{
+			eventURN:   m.FixtureChange.EventURN,
+			receivedAt: m.ReceivedAt,
+			refresh:    true,
+			change:     m.FixtureChange,
+		}, true
+	}
+	if m.Type.Kind() != uof.MessageKindEvent || m.EventURN.EventID() == 0 {
+		return fixtureRequest{}, false
+	}
+	return fixtureRequest{
+		eventURN:   m.EventURN,
+		receivedAt: m.ReceivedAt,
+		refresh:    m.Type == uof.MessageTypeBetSettlement && isDraw(m.EventURN),
+	}, true
+}
+
+func isDraw(u uof.URN) bool {
+	return strings.HasPrefix(string(u), "wns:draw:")
+}
+
+// unseen reports whether fixture of the event is not requested in some of
+// the languages, and marks it requested in all.
+func (f *fixture) unseen(u uof.URN) bool {
+	unseen := false
+	for _, lang := range f.languages {
+		key := uof.UIDWithLang(u.EventID(), lang)
+		if !f.seen.fresh(key) {
+			f.seen.insert(key)
+			unseen = true
+		}
+	}
+	return unseen
+}
+
+// holdEvent starts holding event messages until fixture is received
+func (f *fixture) holdEvent(u uof.URN) {
+	if !f.hold {
+		return
+	}
+	f.Lock()
+	defer f.Unlock()
+	if _, ok := f.held[u.EventID()]; !ok {
+		f.held[u.EventID()] = &heldEvent{pending: len(f.languages)}
+	}
+}
+
+// send message to out, or hold it if event is waiting for fixture
+func (f *fixture) send(m *uof.Message) {
+	f.Lock()
+	f.linkParent(m)
+	if h, ok := f.held[m.EventID]; ok {
+		h.msgs = append(h.msgs, m)
+		f.Unlock()
+		return
 	}
-	return m.FixtureChange.EventURN
+	f.Unlock()
+	f.out <- m
 }
 
-// returns list of fixture changes urns appeared in 'in' during preload
-func (f *fixture) preloadLoop(in <-chan *uof.Message) []uof.URN {
+// release held event messages when fixture request in each language is done.
+// Messages are sent without holding the lock. Event stays held until its
+// queue is drained, so messages received meanwhile are queued after the held
+// ones and the order is kept.
+func (f *fixture) release(eventID int) {
+	f.Lock()
+	h, ok := f.held[eventID]
+	if !ok {
+		f.Unlock()
+		return
+	}
+	if h.pending--; h.pending > 0 || h.releasing {
+		f.Unlock()
+		return
+	}
+	h.releasing = true
+	for {
+		msgs := h.msgs
+		h.msgs = nil
+		if len(msgs) == 0 {
+			delete(f.held, eventID)
+			f.Unlock()
+			return
+		}
+		for _, m := range msgs {
+			f.linkParent(m)
+		}
+		f.Unlock()
+		for _, m := range msgs {
+			f.out <- m
+		}
+		f.Lock()
+	}
+}
+
+// linkParent sets parent stage of the race event message
+func (f *fixture) linkParent(m *uof.Message) {
+	if p, ok := f.parents.get(m.EventID); ok {
+		m.ParentEventURN = p.(uof.URN)
+	}
+}
+
+// returns requests for the events appeared in 'in' during preload. Messages
+// are not held during preload.
+func (f *fixture) preloadLoop(in <-chan *uof.Message) map[uof.URN]fixtureRequest {
+	if f.preloadTo.IsZero() {
+		return nil
+	}
 	done := make(chan struct{})
 
 	f.subProcs.Add(1)
@@ -78,7 +239,7 @@ func (f *fixture) preloadLoop(in <-chan *uof.Message) []uof.URN {
 		close(done)
 	}()
 
-	var urns []uof.URN
+	urns := make(map[uof.URN]fixtureRequest)
 	for {
 		select {
 		case m, ok := <-in:
@@ -86,8 +247,8 @@ func (f *fixture) preloadLoop(in <-chan *uof.Message) []uof.URN {
 				return urns
 			}
 			f.out <- m
-			if u := f.eventURN(m); u != uof.NoURN {
-				urns = append(urns, u)
+			if r, ok := f.request(m); ok && !urns[r.eventURN].refresh {
+				urns[r.eventURN] = r
 			}
 		case <-done:
 			return urns
@@ -104,43 +265,163 @@ func (f *fixture) preload() {
 	for _, lang := range f.languages {
 		go func(lang uof.Lang) {
 			defer wg.Done()
+			if f.cache.Preloaded(lang) {
+				for _, m := range f.cache.Fixtures(lang) {
+					f.diff(m, nil)
+					f.out <- m
+					f.em.insert(uof.UIDWithLang(m.EventID, lang))
+					f.seen.insert(uof.UIDWithLang(m.EventID, lang))
+				}
+				return
+			}
 			in, errc := f.api.Fixtures(lang, f.preloadTo)
 			for x := range in {
-				f.out <- uof.NewFixtureMessage(lang, x, uof.CurrentTimestamp())
+				m := uof.NewFixtureMessage(lang, x, uof.CurrentTimestamp())
+				f.diff(m, nil)
+				f.out <- m
 				f.em.insert(uof.UIDWithLang(x.URN.EventID(), lang))
+				f.seen.insert(uof.UIDWithLang(x.URN.EventID(), lang))
+				f.save(m)
 			}
+			failed := false
 			for err := range errc {
 				f.errc <- err
+				failed = true
+			}
+			if !failed {
+				if err := f.cache.SetPreloaded(lang); err != nil {
+					f.errc <- uof.Notice("cache save", err)
+				}
 			}
 		}(lang)
 	}
 	wg.Wait()
 }
 
-func (f *fixture) getFixture(eventURN uof.URN, receivedAt int, isPreload bool) {
+// getFixture requests event fixture in each language. On refresh fixture is
+// refetched and diff to the previous version is attached, otherwise fresh
+// fixtures are skipped and disk cache is used. Numbers betting draws are
+// requested from the draw summary.
+func (f *fixture) getFixture(r fixtureRequest) {
+	eventURN, receivedAt := r.eventURN, r.receivedAt
 	f.subProcs.Add(len(f.languages))
 	for _, lang := range f.languages {
 		go func(lang uof.Lang) {
 			defer f.subProcs.Done()
+			if r.held {
+				defer f.release(eventURN.EventID())
+			}
 			f.rateLimit <- struct{}{}
 			defer func() { <-f.rateLimit }()
 
 			key := uof.UIDWithLang(eventURN.EventID(), lang)
-			if isPreload && f.em.fresh(key) {
+			if !r.refresh && f.em.fresh(key) {
 				return
 			}
-			buf, err := f.api.Fixture(lang, eventURN)
-			if err != nil {
-				f.errc <- err
-				return
+			f.em.insert(key)
+			if !r.refresh {
+				if m := f.cache.Fixture(lang, eventURN.EventID()); m != nil {
+					f.diff(m, nil)
+					f.stages(m, r)
+					f.out <- m
+					return
+				}
 			}
-			m, err := uof.NewFixtureMessageFromBuf(lang, buf, receivedAt)
+			m, err := f.fetch(lang, eventURN, receivedAt)
 			if err != nil {
+				// next event message retries
+				f.em.remove(key)
+				f.seen.remove(key)
 				f.errc <- err
 				return
 			}
+			f.diff(m, r.change)
+			f.stages(m, r)
 			f.out <- m
-			f.em.insert(key)
+			f.save(m)
 		}(lang)
 	}
 }
+
+// fetch fixture, or draw, message from the api
+func (f *fixture) fetch(lang uof.Lang, eventURN uof.URN, receivedAt int) (*uof.Message, error) {
+	if isDraw(eventURN) {
+		buf, err := f.api.DrawSummary(lang, eventURN)
+		if err != nil {
+			return nil, err
+		}
+		return uof.NewDrawMessageFromBuf(lang, buf, receivedAt)
+	}
+	buf, err := f.api.Fixture(lang, eventURN)
+	if err != nil {
+		return nil, err
+	}
+	return uof.NewFixtureMessageFromBuf(lang, buf, receivedAt)
+}
+
+func (f *fixture) save(m *uof.Message) {
+	if m.Fixture == nil && m.Draw == nil {
+		return
+	}
+	if err := f.cache.Save(m); err != nil {
+		f.errc <- uof.Notice("cache save", err)
+	}
+}
+
+// diff attaches changes from the previous version of the fixture and keeps
+// this one as previous
+func (f *fixture) diff(m *uof.Message, fc *uof.FixtureChange) {
+	if m.Fixture == nil {
+		return
+	}
+	key := uof.UIDWithLang(m.Fixture.URN.EventID(), m.Lang)
+
+	f.Lock()
+	defer f.Unlock()
+	m.FixtureDiff = nil
+	if p, ok := f.prev.get(key); ok {
+		d := m.Fixture.Diff(*p.(*uof.Fixture))
+		if fc != nil {
+			d.ChangeType = fc.ChangeType
+		}
+		m.FixtureDiff = &d
+	}
+	f.prev.set(key, prevFixture(m.Fixture))
+}
+
+// prevFixture copies fields used in diff, fixture sent to out can be changed
+// by the later stages
+func prevFixture(x *uof.Fixture) *uof.Fixture {
+	c := *x
+	c.Competitors = append([]uof.Competitor(nil), x.Competitors...)
+	return &c
+}
+
+// stages links races of the stage fixture to the parent and requests fixture
+// of the unseen direct parent. Races are requested when their messages
+// arrive, and parent of the parent is not followed.
+func (f *fixture) stages(m *uof.Message, r fixtureRequest) {
+	if m.Fixture == nil {
+		return
+	}
+	s := m.Fixture.Stage()
+	if s == nil {
+		return
+	}
+	f.Lock()
+	if s.Parent != nil {
+		f.parents.set(s.ID, s.Parent.URN)
+		m.ParentEventURN = s.Parent.URN
+	}
+	for _, c := range s.Children {
+		f.parents.set(c.ID, s.URN)
+	}
+	f.Unlock()
+
+	if r.parent || s.Parent == nil {
+		return
+	}
+	if u := s.Parent.URN; u.EventID() != 0 && f.unseen(u) {
+		f.getFixture(fixtureRequest{eventURN: u, receivedAt: r.receivedAt, parent: true})
+	}
+}
@@ -1,6 +1,9 @@
 package pipe
 
 import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
 	"sync"
 	"testing"
 	"time"
@@ -32,6 +35,10 @@ func (m *fixtureAPIMock) Fixtures(lang uof.Lang, to time.Time) (<-chan uof.Fixtu
 	return out, errc
 }
 
+func (m *fixtureAPIMock) DrawSummary(lang uof.Lang, eventURN uof.URN) ([]byte, error) {
+	return nil, nil
+}
+
 func TestFixturePipe(t *testing.T) {
 	a := &fixtureAPIMock{}
 	preloadTo := time.Now().Add(time.Hour)
@@ -66,3 +73,332 @@ func fixtureChangeMsg(t *testing.T) *uof.Message {
 	assert.NoError(t, err)
 	return m
 }
+
+type fixtureCountingAPIMock struct {
+	calls map[uof.Lang]int
+	sync.Mutex
+}
+
+func (m *fixtureCountingAPIMock) Fixture(lang uof.Lang, eventURN uof.URN) ([]byte, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.calls[lang]++
+	return ioutil.ReadFile("../testdata/fixture-0.xml")
+}
+
+func (m *fixtureCountingAPIMock) Fixtures(lang uof.Lang, to time.Time) (<-chan uof.Fixture, <-chan error) {
+	return nil, nil
+}
+
+func (m *fixtureCountingAPIMock) DrawSummary(lang uof.Lang, eventURN uof.URN) ([]byte, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.calls[lang]++
+	return ioutil.ReadFile("../testdata/draw-0.xml")
+}
+
+func oddsChangeMsg(t *testing.T) *uof.Message {
+	buf := []byte(`<odds_change event_id="sr:match:1234" product="3" timestamp="1511107200000"/>`)
+	m, err := uof.NewQueueMessage("hi.pre.-.odds_change.1.sr:match.1234.-", buf)
+	assert.NoError(t, err)
+	return m
+}
+
+func TestFixturePipeUnseen(t *testing.T) {
+	a := &fixtureCountingAPIMock{calls: make(map[uof.Lang]int)}
+	f := Fixture(a, []uof.Lang{uof.LangEN, uof.LangDE}, time.Time{})
+	in := make(chan *uof.Message)
+	out, errc := f(in)
+	go func() {
+		for err := range errc {
+			t.Error(err)
+		}
+	}()
+	go func() {
+		in <- oddsChangeMsg(t)
+		in <- oddsChangeMsg(t)
+		close(in)
+	}()
+	var types []uof.MessageType
+	for m := range out {
+		types = append(types, m.Type)
+	}
+	assert.Len(t, types, 4)
+	assert.Equal(t, map[uof.Lang]int{uof.LangEN: 1, uof.LangDE: 1}, a.calls)
+}
+
+type fixtureFailingAPIMock struct {
+	fixtureCountingAPIMock
+}
+
+func (m *fixtureFailingAPIMock) Fixture(lang uof.Lang, eventURN uof.URN) ([]byte, error) {
+	m.Lock()
+	m.calls[lang]++
+	failed := m.calls[lang] == 1
+	m.Unlock()
+	if failed {
+		return nil, fmt.Errorf("failed")
+	}
+	return ioutil.ReadFile("../testdata/fixture-0.xml")
+}
+
+func TestFixturePipeRetry(t *testing.T) {
+	a := &fixtureFailingAPIMock{fixtureCountingAPIMock{calls: make(map[uof.Lang]int)}}
+	f := Fixture(a, []uof.Lang{uof.LangEN}, time.Time{})
+	in := make(chan *uof.Message)
+	out, errc := f(in)
+
+	in <- oddsChangeMsg(t)
+	assert.Equal(t, uof.MessageTypeOddsChange, (<-out).Type)
+	assert.Error(t, <-errc)
+
+	// failed fetch is retried on the next message
+	in <- oddsChangeMsg(t)
+	assert.Equal(t, uof.MessageTypeOddsChange, (<-out).Type)
+	assert.Equal(t, uof.MessageTypeFixture, (<-out).Type)
+	close(in)
+	for range out {
+	}
+	for range errc {
+	}
+	assert.Equal(t, 2, a.calls[uof.LangEN])
+}
+
+func TestFixturePipeHold(t *testing.T) {
+	a := &fixtureCountingAPIMock{calls: make(map[uof.Lang]int)}
+	f := Fixture(a, []uof.Lang{uof.LangEN, uof.LangDE}, time.Time{}, WithHoldUntilFixture())
+	in := make(chan *uof.Message)
+	out, errc := f(in)
+	go func() {
+		for err := range errc {
+			t.Error(err)
+		}
+	}()
+	go func() {
+		in <- oddsChangeMsg(t)
+		in <- uof.NewSimpleConnnectionMessage(uof.ConnectionStatusUp)
+		in <- oddsChangeMsg(t)
+		close(in)
+	}()
+	var types []uof.MessageType
+	for m := range out {
+		types = append(types, m.Type)
+	}
+	// odds changes wait for fixtures in both languages
+	assert.Len(t, types, 5)
+	assert.Equal(t, []uof.MessageType{uof.MessageTypeOddsChange, uof.MessageTypeOddsChange}, types[3:])
+	assert.Contains(t, types[:3], uof.MessageTypeConnection)
+}
+
+func TestFixturePipeHoldRelease(t *testing.T) {
+	a := &fixtureCountingAPIMock{calls: make(map[uof.Lang]int)}
+	f := Fixture(a, []uof.Lang{uof.LangEN, uof.LangDE}, time.Time{}, WithHoldUntilFixture())
+	in := make(chan *uof.Message)
+	out, errc := f(in)
+	go func() {
+		for err := range errc {
+			t.Error(err)
+		}
+	}()
+
+	m1 := oddsChangeMsg(t)
+	in <- m1
+	assert.Equal(t, uof.MessageTypeFixture, (<-out).Type)
+	assert.Equal(t, uof.MessageTypeFixture, (<-out).Type)
+
+	// release is waiting on out, loop still accepts new event messages
+	m2, m3 := oddsChangeMsg(t), oddsChangeMsg(t)
+	for _, m := range []*uof.Message{m2, m3} {
+		select {
+		case in <- m:
+		case <-time.After(time.Second):
+			t.Fatal("loop blocked by release")
+		}
+	}
+	close(in)
+	var msgs []*uof.Message
+	for m := range out {
+		msgs = append(msgs, m)
+	}
+	assert.Equal(t, []*uof.Message{m1, m2, m3}, msgs)
+}
+
+type fixtureChangingAPIMock struct {
+	calls int
+	sync.Mutex
+}
+
+func (m *fixtureChangingAPIMock) Fixture(lang uof.Lang, eventURN uof.URN) ([]byte, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.calls++
+	buf, err := ioutil.ReadFile("../testdata/fixture-0.xml")
+	if m.calls > 1 {
+		buf = bytes.Replace(buf, []byte(`start_time="2019-05-08T19:00:00+00:00"`), []byte(`start_time="2019-05-08T21:00:00+00:00"`), 1)
+	}
+	return buf, err
+}
+
+func (m *fixtureChangingAPIMock) Fixtures(lang uof.Lang, to time.Time) (<-chan uof.Fixture, <-chan error) {
+	return nil, nil
+}
+
+func (m *fixtureChangingAPIMock) DrawSummary(lang uof.Lang, eventURN uof.URN) ([]byte, error) {
+	return nil, nil
+}
+
+func TestFixturePipeDiff(t *testing.T) {
+	a := &fixtureChangingAPIMock{}
+	f := Fixture(a, []uof.Lang{uof.LangEN}, time.Time{})
+	in := make(chan *uof.Message)
+	out, errc := f(in)
+	go func() {
+		for err := range errc {
+			t.Error(err)
+		}
+	}()
+
+	in <- fixtureChangeMsg(t)
+	<-out
+	m := <-out
+	assert.Equal(t, uof.MessageTypeFixture, m.Type)
+	assert.Nil(t, m.FixtureDiff)
+
+	fc := fixtureChangeMsg(t)
+	ct := uof.FixtureChangeTypeTime
+	fc.FixtureChange.ChangeType = &ct
+	in <- fc
+	<-out
+	m = <-out
+	close(in)
+	for range out {
+	}
+
+	assert.NotNil(t, m.FixtureDiff)
+	assert.Equal(t, ct, *m.FixtureDiff.ChangeType)
+	assert.Equal(t, 2*time.Hour, m.FixtureDiff.StartTime.Moved())
+	assert.Nil(t, m.FixtureDiff.Status)
+}
+
+type fixtureStagesAPIMock struct {
+	calls []uof.URN
+	sync.Mutex
+}
+
+func (m *fixtureStagesAPIMock) Fixture(lang uof.Lang, eventURN uof.URN) ([]byte, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.calls = append(m.calls, eventURN)
+	if eventURN == "vdr:stage:1064551" {
+		return ioutil.ReadFile("../testdata/fixture-6.xml")
+	}
+	buf, err := ioutil.ReadFile("../testdata/fixture-7.xml")
+	return bytes.Replace(buf, []byte(`fixture id="vdr:stage:1064552"`), []byte(`fixture id="`+eventURN+`"`), 1), err
+}
+
+func (m *fixtureStagesAPIMock) Fixtures(lang uof.Lang, to time.Time) (<-chan uof.Fixture, <-chan error) {
+	return nil, nil
+}
+
+func (m *fixtureStagesAPIMock) DrawSummary(lang uof.Lang, eventURN uof.URN) ([]byte, error) {
+	return nil, nil
+}
+
+func TestFixturePipeStages(t *testing.T) {
+	a := &fixtureStagesAPIMock{}
+	f := Fixture(a, []uof.Lang{uof.LangEN}, time.Time{})
+	in := make(chan *uof.Message)
+	out, errc := f(in)
+	go func() {
+		for err := range errc {
+			t.Error(err)
+		}
+	}()
+	oddsChange := func() *uof.Message {
+		buf := []byte(`<odds_change event_id="vdr:stage:1064552" product="10" timestamp="1583848800000"/>`)
+		m, err := uof.NewQueueMessage("hi.pre.-.odds_change.190.vdr:stage.1064552.-", buf)
+		assert.NoError(t, err)
+		return m
+	}
+
+	in <- oddsChange()
+	// odds change, race and parent fixtures; other races are not requested
+	fixtures := make(map[uof.URN]*uof.Message)
+	for i := 0; i < 3; i++ {
+		m := <-out
+		if m.Type == uof.MessageTypeFixture {
+			fixtures[m.Fixture.URN] = m
+		}
+	}
+	assert.Len(t, fixtures, 2)
+	assert.Equal(t, uof.URN("vdr:stage:1064551"), fixtures["vdr:stage:1064552"].ParentEventURN)
+	assert.NotNil(t, fixtures["vdr:stage:1064551"])
+
+	in <- oddsChange()
+	m := <-out
+	assert.Equal(t, uof.URN("vdr:stage:1064551"), m.ParentEventURN)
+	assert.Equal(t, []uof.URN{"vdr:stage:1064552", "vdr:stage:1064551"}, a.calls)
+
+	// other race is linked to the parent from its fixture
+	buf := []byte(`<odds_change event_id="vdr:stage:1064553" product="10" timestamp="1583848800000"/>`)
+	m, err := uof.NewQueueMessage("hi.pre.-.odds_change.190.vdr:stage.1064553.-", buf)
+	assert.NoError(t, err)
+	in <- m
+	m = <-out
+	assert.Equal(t, uof.URN("vdr:stage:1064551"), m.ParentEventURN)
+	m = <-out
+	assert.Equal(t, uof.URN("vdr:stage:1064551"), m.ParentEventURN)
+	close(in)
+	for range out {
+	}
+	assert.Len(t, a.calls, 3)
+}
+
+func TestFixturePrevCopy(t *testing.T) {
+	f := &fixture{prev: newExpireMap(time.Hour)}
+	m := uof.NewFixtureMessage(uof.LangEN, uof.Fixture{URN: "sr:match:1", Status: "not_started"}, 0)
+	f.diff(m, nil)
+	// change of the sent fixture doesn't change the previous version
+	m.Fixture.Status = "live"
+	m2 := uof.NewFixtureMessage(uof.LangEN, uof.Fixture{URN: "sr:match:1", Status: "live"}, 0)
+	f.diff(m2, nil)
+	assert.NotNil(t, m2.FixtureDiff.Status)
+	assert.Equal(t, "not_started", m2.FixtureDiff.Status.From)
+}
+
+func TestFixturePipeDraw(t *testing.T) {
+	a := &fixtureCountingAPIMock{calls: make(map[uof.Lang]int)}
+	f := Fixture(a, []uof.Lang{uof.LangEN}, time.Time{})
+	in := make(chan *uof.Message)
+	out, errc := f(in)
+	go func() {
+		for err := range errc {
+			t.Error(err)
+		}
+	}()
+	msg := func(typ, body string) *uof.Message {
+		m, err := uof.NewQueueMessage("hi.pre.-."+typ+".108.wns:draw.1221541870.-", []byte(body))
+		assert.NoError(t, err)
+		return m
+	}
+
+	in <- msg("odds_change", `<odds_change event_id="wns:draw:1221541870" product="7" timestamp="1585850400000"/>`)
+	var draw *uof.Message
+	for i := 0; i < 2; i++ {
+		if m := <-out; m.Type == uof.MessageTypeDraw {
+			draw = m
+		}
+	}
+	assert.NotNil(t, draw)
+	assert.Equal(t, "Lotto 6 aus 49", draw.Draw.Lottery.Name)
+
+	// settlement refreshes draw
+	in <- msg("bet_settlement", `<bet_settlement event_id="wns:draw:1221541870" product="7" timestamp="1585850500000" certainty="2"><outcomes/></bet_settlement>`)
+	close(in)
+	types := make(map[uof.MessageType]int)
+	for m := range out {
+		types[m.Type]++
+	}
+	assert.Equal(t, map[uof.MessageType]int{uof.MessageTypeBetSettlement: 1, uof.MessageTypeDraw: 1}, types)
+	assert.Equal(t, 2, a.calls[uof.LangEN])
+}
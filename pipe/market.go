@@ -1,7 +1,7 @@
 package pipe
 
 import (
-	"strings"
+	"strconv"
 	"sync"
 	"time"
 
@@ -21,25 +21,41 @@ type markets struct {
 	out       chan<- *uof.Message
 	rateLimit chan struct{}
 	subProcs  *sync.WaitGroup
+	cache     *DiskCache
+	refresh   time.Duration
+	current   map[uof.Lang]uof.MarketDescriptions // last received all markets
+	sync.Mutex
 }
 
-// getting all markets on the start
-func Markets(api marketsAPI, languages []uof.Lang) InnerStage {
+// Markets gets all markets on the start and variant markets found in odds
+// change messages. With WithMarketsRefresh option all markets are
+// periodically refreshed, message with only changed markets is emitted.
+func Markets(api marketsAPI, languages []uof.Lang, opts ...Option) InnerStage {
 	var wg sync.WaitGroup
+	o := newOptions(24*time.Hour, opts)
 	m := &markets{
 		api:       api,
 		languages: languages,
-		em:        newExpireMap(24 * time.Hour),
+		em:        newExpireMapWithPolicy(o.cachePolicy),
 		subProcs:  &wg,
 		rateLimit: make(chan struct{}, ConcurentAPICallsLimit),
+		cache:     o.cache,
+		refresh:   o.marketsRefresh,
+		current:   make(map[uof.Lang]uof.MarketDescriptions),
 	}
 	return StageWithSubProcessesSync(m.loop)
 }
 
 func (s *markets) loop(in <-chan *uof.Message, out chan<- *uof.Message, errc chan<- error) *sync.WaitGroup {
 	s.out, s.errc = out, errc
+	done := make(chan struct{})
+	defer close(done)
 
 	s.getAll()
+	if s.refresh > 0 {
+		s.subProcs.Add(1)
+		go s.refreshLoop(done)
+	}
 	for m := range in {
 		out <- m
 		if m.Is(uof.MessageTypeOddsChange) {
@@ -59,21 +75,69 @@ func (s *markets) getAll() {
 		go func(lang uof.Lang) {
 			defer s.subProcs.Done()
 
+			if m := s.cache.Markets(lang); m != nil {
+				s.setCurrent(lang, m.Markets)
+				s.out <- m
+				return
+			}
 			ms, err := s.api.Markets(lang)
 			if err != nil {
 				s.errc <- err
 				return
 			}
-			s.out <- uof.NewMarketsMessage(lang, ms, requestedAt)
+			s.setCurrent(lang, ms)
+			s.emit(uof.NewMarketsMessage(lang, ms, requestedAt))
 		}(lang)
 	}
 }
 
-func (s *markets) variantMarket(marketID int, variant string, requestedAt int) {
-	if strings.HasPrefix(variant, "pre:playerprops") {
-		// TODO: it is not working for this type of variant markets
+func (s *markets) refreshLoop(done <-chan struct{}) {
+	defer s.subProcs.Done()
+	t := time.NewTicker(s.refresh)
+	defer t.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+			for _, lang := range s.languages {
+				s.refreshLang(lang)
+			}
+		}
+	}
+}
+
+// refreshLang gets all markets and emits changes from the previous call
+func (s *markets) refreshLang(lang uof.Lang) {
+	requestedAt := uof.CurrentTimestamp()
+	ms, err := s.api.Markets(lang)
+	if err != nil {
+		s.errc <- err
 		return
 	}
+	d := ms.Diff(s.setCurrent(lang, ms))
+	if d.Empty() {
+		return
+	}
+	s.out <- uof.NewMarketsDiffMessage(lang, d, requestedAt)
+	if err := s.cache.Save(uof.NewMarketsMessage(lang, ms, requestedAt)); err != nil {
+		s.errc <- uof.Notice("cache save", err)
+	}
+}
+
+// setCurrent replaces all markets for the language, returns previous
+func (s *markets) setCurrent(lang uof.Lang, ms uof.MarketDescriptions) uof.MarketDescriptions {
+	s.Lock()
+	defer s.Unlock()
+	prev := s.current[lang]
+	s.current[lang] = ms
+	return prev
+}
+
+// Descriptions are cached by market id and variant. Dynamic variants
+// (pre:playerprops:<event>:<player>, pre:outcometext:<id>) are unique for the
+// event, so each event still gets its own descriptions.
+func (s *markets) variantMarket(marketID int, variant string, requestedAt int) {
 	s.subProcs.Add(len(s.languages))
 
 	for _, lang := range s.languages {
@@ -82,18 +146,36 @@ func (s *markets) variantMarket(marketID int, variant string, requestedAt int) {
 			s.rateLimit <- struct{}{}
 			defer func() { <-s.rateLimit }()
 
-			key := uof.UIDWithLang(uof.Hash(variant)<<32|marketID, lang)
+			key := variantKey(marketID, variant, lang)
 			if s.em.fresh(key) {
 				return
 			}
+			if m := s.cache.MarketVariant(lang, marketID, variant); m != nil {
+				s.out <- m
+				s.em.insert(key)
+				return
+			}
 
 			ms, err := s.api.MarketVariant(lang, marketID, variant)
 			if err != nil {
 				s.errc <- err
 				return
 			}
-			s.out <- uof.NewMarketsMessage(lang, ms, requestedAt)
+			s.emit(uof.NewMarketVariantMessage(lang, variant, ms, requestedAt))
 			s.em.insert(key)
 		}(lang)
 	}
 }
+
+// emit message received from api and save it to the cache
+func (s *markets) emit(m *uof.Message) {
+	s.out <- m
+	if err := s.cache.Save(m); err != nil {
+		s.errc <- uof.Notice("cache save", err)
+	}
+}
+
+// variantKey identifies market variant description in the expire map
+func variantKey(marketID int, variant string, lang uof.Lang) int {
+	return uof.UIDWithLang(uof.Hash(strconv.Itoa(marketID)+"/"+variant), lang)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/minus5/go-uof-sdk"
 	"github.com/stretchr/testify/assert"
@@ -58,3 +59,91 @@ func TestMarketsPipe(t *testing.T) {
 	assert.True(t, found)
 
 }
+
+func TestMarketsPipePlayerProps(t *testing.T) {
+	a := &marketsAPIMock{requests: make(map[string]struct{})}
+	ms := Markets(a, []uof.Lang{uof.LangEN})
+	in := make(chan *uof.Message)
+	out, _ := ms(in)
+
+	buf := []byte(`<odds_change product="3" event_id="sr:match:35432179" timestamp="1">
+		<odds>
+			<market id="768" specifiers="variant=pre:playerprops:35432179:608000|total=19.5">
+				<outcome id="sr:player:608000:12" odds="1.9"/>
+			</market>
+			<market id="534" specifiers="variant=pre:outcometext:9919">
+				<outcome id="pre:outcometext:5" odds="1.9"/>
+			</market>
+			<market id="768" specifiers="variant=pre:playerprops:35432179:608000|total=20.5">
+				<outcome id="sr:player:608000:12" odds="1.9"/>
+			</market>
+		</odds>
+	</odds_change>`)
+	m, err := uof.NewQueueMessage("hi.pre.-.odds_change.1.sr:match.35432179.-", buf)
+	assert.NoError(t, err)
+	in <- m
+	close(in)
+	for range out {
+	}
+
+	assert.Len(t, a.requests, 2)
+	_, found := a.requests["en 768 pre:playerprops:35432179:608000"]
+	assert.True(t, found)
+	_, found = a.requests["en 534 pre:outcometext:9919"]
+	assert.True(t, found)
+}
+
+type marketsRefreshAPI struct {
+	responses []uof.MarketDescriptions
+	sync.Mutex
+}
+
+func (a *marketsRefreshAPI) Markets(lang uof.Lang) (uof.MarketDescriptions, error) {
+	a.Lock()
+	defer a.Unlock()
+	ms := a.responses[0]
+	if len(a.responses) > 1 {
+		a.responses = a.responses[1:]
+	}
+	return ms, nil
+}
+
+func (a *marketsRefreshAPI) MarketVariant(lang uof.Lang, marketID int, variant string) (uof.MarketDescriptions, error) {
+	return nil, nil
+}
+
+func TestMarketsPipeRefresh(t *testing.T) {
+	a := &marketsRefreshAPI{responses: []uof.MarketDescriptions{
+		{{ID: 1, Name: "1x2"}, {ID: 18, Name: "Total"}},
+		{{ID: 1, Name: "1x2"}, {ID: 18, Name: "Total"}},
+		{{ID: 1, Name: "1x2"}, {ID: 18, Name: "Totals"}, {ID: 16, Name: "Handicap"}},
+	}}
+	ms := Markets(a, []uof.Lang{uof.LangEN}, WithMarketsRefresh(10*time.Millisecond))
+	in := make(chan *uof.Message)
+	out, _ := ms(in)
+
+	m := <-out
+	assert.Nil(t, m.MarketsDiff)
+	assert.Len(t, m.Markets, 2)
+
+	// unchanged refresh is not emitted
+	m = <-out
+	assert.NotNil(t, m.MarketsDiff)
+	assert.Equal(t, uof.MessageTypeMarkets, m.Type)
+	assert.Equal(t, []int{16}, ids(m.MarketsDiff.Added))
+	assert.Equal(t, []int{18}, ids(m.MarketsDiff.Changed))
+	assert.Len(t, m.MarketsDiff.Removed, 0)
+	assert.Len(t, m.Markets, 2)
+
+	close(in)
+	for range out {
+	}
+}
+
+func ids(ms uof.MarketDescriptions) []int {
+	var ids []int
+	for _, m := range ms {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
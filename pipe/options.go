@@ -0,0 +1,64 @@
+package pipe
+
+import "time"
+
+// Option configures lexicon stages: Markets, Fixture and Player.
+type Option func(*options)
+
+type options struct {
+	cache          *DiskCache
+	cachePolicy    CachePolicy
+	marketsRefresh time.Duration
+	// fixture stage holds event messages until fixture is received
+	holdUntilFixture bool
+}
+
+func newOptions(defaultTTL time.Duration, opts []Option) options {
+	o := options{
+		cachePolicy: CachePolicy{TTL: defaultTTL, MaxSize: defaultCacheMaxSize},
+	}
+	for _, fn := range opts {
+		fn(&o)
+	}
+	return o
+}
+
+// WithDiskCache stage first looks for the fresh lexicon message in the disk
+// cache and calls api only for missing or stale entries. Messages received
+// from api are saved to the cache.
+func WithDiskCache(c *DiskCache) Option {
+	return func(o *options) {
+		o.cache = c
+	}
+}
+
+// WithCachePolicy sets TTL, size limit and metrics of the stage in memory
+// cache. Zero values keep stage defaults, negative MaxSize is unlimited.
+func WithCachePolicy(p CachePolicy) Option {
+	return func(o *options) {
+		if p.TTL == 0 {
+			p.TTL = o.cachePolicy.TTL
+		}
+		if p.MaxSize == 0 {
+			p.MaxSize = o.cachePolicy.MaxSize
+		}
+		o.cachePolicy = p
+	}
+}
+
+// WithMarketsRefresh Markets stage gets all markets every interval and emits
+// changed markets. Zero interval disables refresh.
+func WithMarketsRefresh(interval time.Duration) Option {
+	return func(o *options) {
+		o.marketsRefresh = interval
+	}
+}
+
+// WithHoldUntilFixture Fixture stage holds messages of the event, for which
+// fixture is not yet requested, until the fixture is emitted in all languages.
+// Consumers will always get the fixture before the event odds.
+func WithHoldUntilFixture() Option {
+	return func(o *options) {
+		o.holdUntilFixture = true
+	}
+}
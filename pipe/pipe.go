@@ -2,7 +2,6 @@ package pipe
 
 import (
 	"sync"
-	"time"
 
 	"github.com/minus5/go-uof-sdk"
 )
@@ -235,64 +234,3 @@ func Simple(each func(m *uof.Message) error) InnerStage {
 		return out, errc
 	}
 }
-
-type expireMap struct {
-	m        map[int]int
-	interval time.Duration
-	sync.Mutex
-}
-
-func newExpireMap(expireAfter time.Duration) *expireMap {
-	em := &expireMap{
-		m:        make(map[int]int),
-		interval: expireAfter,
-	}
-	go func() {
-		time.Sleep(expireAfter * 2)
-		em.cleanup()
-	}()
-	return em
-}
-
-func (em *expireMap) cleanup() {
-	em.Lock()
-	defer em.Unlock()
-
-	for k, v := range em.m {
-		if em.expired(v) {
-			delete(em.m, k)
-		}
-	}
-}
-
-func (em *expireMap) expired(v int) bool {
-	return v < em.checkpoint()
-}
-
-func (em *expireMap) fresh(k int) bool {
-	em.Lock()
-	defer em.Unlock()
-
-	if v, ok := em.m[k]; ok {
-		return v > em.checkpoint()
-	}
-	return false
-}
-
-func (em *expireMap) checkpoint() int {
-	return int(time.Now().UnixNano()) - int(em.interval)
-}
-
-func (em *expireMap) insert(key int) {
-	em.Lock()
-	defer em.Unlock()
-
-	em.m[key] = int(time.Now().UnixNano())
-}
-
-func (em *expireMap) remove(key int) {
-	em.Lock()
-	defer em.Unlock()
-
-	delete(em.m, key)
-}
@@ -12,3 +12,86 @@ func TestExpireMap(t *testing.T) {
 	em.insert(1)
 	assert.True(t, em.fresh(1))
 }
+
+func TestExpireMapTTL(t *testing.T) {
+	m := &CacheMetrics{}
+	em := newExpireMapWithPolicy(CachePolicy{TTL: 10 * time.Millisecond, Metrics: m})
+
+	assert.False(t, em.fresh(1))
+	em.insert(1)
+	em.insert(2)
+	assert.True(t, em.fresh(1))
+	assert.Equal(t, int64(2), m.Size())
+
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, em.fresh(1))
+	assert.Equal(t, int64(1), m.Size())
+	em.cleanup()
+	assert.Equal(t, int64(0), m.Size())
+	assert.Len(t, em.items, 0)
+
+	assert.Equal(t, int64(1), m.Hits())
+	assert.Equal(t, int64(2), m.Misses())
+	assert.Equal(t, int64(2), m.Evictions())
+
+	em.insert(3)
+	em.remove(3)
+	assert.False(t, em.fresh(3))
+	assert.Equal(t, int64(0), m.Size())
+}
+
+func TestExpireMapLRU(t *testing.T) {
+	m := &CacheMetrics{}
+	em := newExpireMapWithPolicy(CachePolicy{TTL: time.Minute, MaxSize: 2, Metrics: m})
+
+	em.insert(1)
+	em.insert(2)
+	assert.True(t, em.fresh(1)) // 2 is now least recently used
+	em.insert(3)
+	assert.True(t, em.fresh(1))
+	assert.False(t, em.fresh(2))
+	assert.True(t, em.fresh(3))
+	assert.Equal(t, int64(2), m.Size())
+	assert.Equal(t, int64(1), m.Evictions())
+}
+
+func TestExpireMapPolicy(t *testing.T) {
+	em := newExpireMapWithPolicy(CachePolicy{TTL: time.Minute})
+	assert.Equal(t, defaultCacheMaxSize, em.maxSize)
+
+	em = newExpireMapWithPolicy(CachePolicy{TTL: time.Minute, MaxSize: -1})
+	for i := 0; i < 10; i++ {
+		em.insert(i)
+	}
+	assert.Len(t, em.items, 10)
+}
+
+func TestExpireMapCleanupOnInsert(t *testing.T) {
+	m := &CacheMetrics{}
+	em := newExpireMapWithPolicy(CachePolicy{TTL: 10 * time.Millisecond, Metrics: m})
+	em.insert(1)
+	em.insert(2)
+	time.Sleep(20 * time.Millisecond)
+
+	// expired entries are removed on insert after the cleanup interval
+	em.nextCleanup = time.Now()
+	em.insert(3)
+	assert.Len(t, em.items, 1)
+	assert.Equal(t, int64(1), m.Size())
+	assert.Equal(t, int64(2), m.Evictions())
+}
+
+func TestExpireMapValues(t *testing.T) {
+	em := newExpireMapWithPolicy(CachePolicy{TTL: time.Minute, MaxSize: 2})
+	em.set(1, "a")
+	em.set(2, "b")
+	em.set(1, "c")
+	em.set(3, "d") // evicts 2
+
+	v, ok := em.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "c", v)
+	_, ok = em.get(2)
+	assert.False(t, ok)
+	assert.Len(t, em.items, 2)
+}
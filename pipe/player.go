@@ -19,15 +19,18 @@ type player struct {
 	out       chan<- *uof.Message
 	rateLimit chan struct{}
 	subProcs  *sync.WaitGroup
+	cache     *DiskCache
 }
 
-func Player(api playerAPI, languages []uof.Lang) InnerStage {
+func Player(api playerAPI, languages []uof.Lang, opts ...Option) InnerStage {
+	o := newOptions(time.Hour, opts)
 	p := &player{
 		api:       api,
 		languages: languages,
-		em:        newExpireMap(time.Hour),
+		em:        newExpireMapWithPolicy(o.cachePolicy),
 		subProcs:  &sync.WaitGroup{},
 		rateLimit: make(chan struct{}, ConcurentAPICallsLimit),
+		cache:     o.cache,
 	}
 	return StageWithSubProcessesSync(p.loop)
 }
@@ -59,13 +62,21 @@ func (p *player) get(playerID, requestedAt int) {
 				return
 			}
 			p.em.insert(key)
+			if m := p.cache.Player(lang, playerID); m != nil {
+				p.out <- m
+				return
+			}
 			ap, err := p.api.Player(lang, playerID)
 			if err != nil {
 				p.em.remove(key)
 				p.errc <- err
 				return
 			}
-			p.out <- uof.NewPlayerMessage(lang, ap, requestedAt)
+			m := uof.NewPlayerMessage(lang, ap, requestedAt)
+			p.out <- m
+			if err := p.cache.Save(m); err != nil {
+				p.errc <- uof.Notice("cache save", err)
+			}
 		}(lang)
 	}
 }
@@ -12,7 +12,7 @@ import (
 // on start recover all after timestamp or full
 // on reconnect recover all after timestamp
 // on alive with subscribed = 0, revocer that producer with last valid ts
-// TODO: counting on number of requests per period
+// number of recovery requests per period is limited in the api package
 
 // Recovery requests limits: https://docs.betradar.com/display/BD/UOF+-+Access+restrictions+for+odds+recovery
 // Recovery sequence explained: https://docs.betradar.com/display/BD/UOF+-+Recovery+using+API
@@ -29,6 +29,7 @@ type recoveryProducer struct {
 	aliveTimestamp        int                // last alive timestamp
 	requestID             int                // last recovery requestID
 	statusChangedAt       int                // last change of the status
+	serverTimeOffset      int                // server clock offset in milliseconds
 	recoveryRequestCancel context.CancelFunc
 }
 
@@ -47,8 +48,10 @@ func (p *recoveryProducer) setStatus(newStatus uof.ProducerStatus) {
 // If producer is back more than recovery window (defined for each producer)
 // it has to make full recovery (forced with timestamp = 0).
 // Otherwise recovery after timestamp is done.
+// Alive timestamps are in server time so the local clock is corrected with the
+// known server time offset.
 func (p *recoveryProducer) recoveryTimestamp() int {
-	if uof.CurrentTimestamp()-p.aliveTimestamp >= p.producer.RecoveryWindow() {
+	if uof.CurrentTimestamp()+p.serverTimeOffset-p.aliveTimestamp >= p.producer.RecoveryWindow() {
 		return 0
 	}
 	return p.aliveTimestamp
@@ -66,6 +69,20 @@ type recoveryAPI interface {
 	RequestRecovery(producer uof.Producer, timestamp int, requestID int) error
 }
 
+// serverClock is optionally implemented by recoveryAPI to report difference
+// between server and local clock.
+type serverClock interface {
+	ServerTimeOffset() time.Duration
+}
+
+// server time offset in milliseconds
+func (r *recovery) serverTimeOffset() int {
+	if sc, ok := r.api.(serverClock); ok {
+		return int(sc.ServerTimeOffset() / time.Millisecond)
+	}
+	return 0
+}
+
 func newRecovery(api recoveryAPI, producers uof.ProducersChange) *recovery {
 	r := &recovery{
 		api:      api,
@@ -101,6 +118,7 @@ func (r *recovery) cancelSubProcs() {
 func (r *recovery) requestRecovery(p *recoveryProducer) {
 	p.setStatus(uof.ProducerStatusInRecovery)
 	p.requestID = r.nextRequestID()
+	p.serverTimeOffset = r.serverTimeOffset()
 
 	if cancel := p.recoveryRequestCancel; cancel != nil {
 		cancel()
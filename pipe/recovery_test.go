@@ -37,6 +37,10 @@ func TestRecoveryTimestamp(t *testing.T) {
 	assert.Equal(t, rp.aliveTimestamp, rp.recoveryTimestamp())
 	rp.aliveTimestamp = cs - rp.producer.RecoveryWindow()
 	assert.Equal(t, int(0), rp.recoveryTimestamp())
+
+	// server clock behind local clock
+	rp.serverTimeOffset = -1000
+	assert.Equal(t, rp.aliveTimestamp, rp.recoveryTimestamp())
 }
 
 func TestRecoveryStateMachine(t *testing.T) {
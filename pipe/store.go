@@ -52,15 +52,17 @@ func filename(m *uof.Message) string {
 	case uof.MessageKindLexicon:
 		switch m.Type {
 		case uof.MessageTypePlayer:
-			return fmt.Sprintf("/state/%s/players/%08d/%13d", m.Lang, m.Player.ID, m.RequestedAt)
+			return fmt.Sprintf("%s/%13d", playerDir(m.Lang, m.Player.ID), m.RequestedAt)
 		case uof.MessageTypeMarkets:
-			if len(m.Markets) > 1 {
-				return fmt.Sprintf("/state/%s/markets/%s/%13d", m.Lang, m.Lang, m.RequestedAt)
+			if m.MarketsDiff != nil {
+				return fmt.Sprintf("%s/diff/%13d", marketsDir(m.Lang), m.RequestedAt)
 			}
-			s := m.Markets[0]
-			return fmt.Sprintf("/state/%s/markets/%08d-%08d/%13d", m.Lang, s.ID, s.VariantID, m.RequestedAt)
-		case uof.MessageTypeFixture:
-			return fmt.Sprintf("/state/%s/fixtures/%08d/%13d", m.Lang, m.EventID, m.RequestedAt)
+			if m.MarketVariant == "" || len(m.Markets) == 0 {
+				return fmt.Sprintf("%s/%13d", marketsDir(m.Lang), m.RequestedAt)
+			}
+			return fmt.Sprintf("%s/%13d", marketVariantDir(m.Lang, m.Markets[0].ID, uof.Hash(m.MarketVariant)), m.RequestedAt)
+		case uof.MessageTypeFixture, uof.MessageTypeDraw:
+			return fmt.Sprintf("%s/%13d", fixtureDir(m.Lang, m.EventID), m.RequestedAt)
 		}
 	case uof.MessageKindSystem:
 		return fmt.Sprintf("log/system/%13d-%s/%13d", m.ReceivedAt, m.Type, m.ReceivedAt)
@@ -68,6 +70,26 @@ func filename(m *uof.Message) string {
 	return fmt.Sprintf("/other/%13d-%s", m.ReceivedAt, m.Type)
 }
 
+func playerDir(lang uof.Lang, playerID int) string {
+	return fmt.Sprintf("/state/%s/players/%08d", lang, playerID)
+}
+
+func marketsDir(lang uof.Lang) string {
+	return fmt.Sprintf("/state/%s/markets/%s", lang, lang)
+}
+
+func marketVariantDir(lang uof.Lang, marketID, variantID int) string {
+	return fmt.Sprintf("/state/%s/markets/%08d-%08d", lang, marketID, variantID)
+}
+
+func fixtureDir(lang uof.Lang, eventID int) string {
+	return fmt.Sprintf("/state/%s/fixtures/%08d", lang, eventID)
+}
+
+func preloadDir(lang uof.Lang) string {
+	return fmt.Sprintf("/state/%s/preload", lang)
+}
+
 func save(filename string, buf []byte) error {
 	dir, _ := path.Split(filename)
 	err := os.MkdirAll(dir, os.ModePerm)
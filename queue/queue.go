@@ -99,7 +99,10 @@ func (c *Connection) drain(out chan<- *uof.Message, errc chan<- error) {
 		m, err := uof.NewQueueMessage(m.RoutingKey, m.Body)
 		if err != nil {
 			errc <- uof.Notice("conn.DeliveryParse", err)
-			continue
+			if m == nil {
+				continue
+			}
+			// unknown enum values are reported, message is still delivered
 		}
 		out <- m
 	}
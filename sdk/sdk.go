@@ -2,16 +2,27 @@ package sdk
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/minus5/go-uof-sdk"
 	"github.com/minus5/go-uof-sdk/api"
+	"github.com/minus5/go-uof-sdk/lexicon"
 	"github.com/minus5/go-uof-sdk/pipe"
 	"github.com/minus5/go-uof-sdk/queue"
 )
 
 var defaultLanguages = uof.Languages("en,de")
 
+const (
+	defaultTokenExpiryWarning = 7 * 24 * time.Hour
+	defaultMarketsRefresh     = time.Hour
+	// how often to refresh bookmaker details for the token expiry check
+	tokenExpiryCheckInterval = 12 * time.Hour
+)
+
 // ErrorListenerFunc listens all SDK errors
 type ErrorListenerFunc func(err error)
 
@@ -26,6 +37,17 @@ type Config struct {
 	Env           uof.Environment
 	Languages     []uof.Lang
 	ErrorListener ErrorListenerFunc
+	// notice is raised if access token expires sooner than this
+	TokenExpiryWarning time.Duration
+	APIOptions         []api.Option
+	Lexicon            *lexicon.Store
+	LexiconFetch       bool
+	LexiconCache       *pipe.DiskCache
+	MarketsCache       pipe.CachePolicy
+	FixtureCache       pipe.CachePolicy
+	PlayerCache        pipe.CachePolicy
+	MarketsRefresh     time.Duration
+	HoldUntilFixture   bool
 }
 
 // Option sets attributes on the Config.
@@ -43,7 +65,7 @@ func Run(ctx context.Context, options ...Option) error {
 		return err
 	}
 	if c.Replay != nil {
-		rpl, err := api.Replay(ctx, c.Token)
+		rpl, err := api.Replay(ctx, c.Token, c.APIOptions...)
 		if err != nil {
 			return err
 		}
@@ -52,12 +74,28 @@ func Run(ctx context.Context, options ...Option) error {
 		}
 	}
 
-	stages := []pipe.InnerStage{
-		pipe.Markets(apiConn, c.Languages),
-		pipe.Fixture(apiConn, c.Languages, c.Fixtures),
-		pipe.Player(apiConn, c.Languages),
-		pipe.BetStop(),
+	cache := pipe.WithDiskCache(c.LexiconCache)
+	fixtureOpts := []pipe.Option{cache, pipe.WithCachePolicy(c.FixtureCache)}
+	if c.HoldUntilFixture {
+		fixtureOpts = append(fixtureOpts, pipe.WithHoldUntilFixture())
+	}
+	var stages []pipe.InnerStage
+	if c.TokenExpiryWarning > 0 {
+		stages = append(stages, tokenExpiry(ctx, apiConn, c.TokenExpiryWarning))
+	}
+	stages = append(stages,
+		pipe.Markets(apiConn, c.Languages, cache, pipe.WithCachePolicy(c.MarketsCache),
+			pipe.WithMarketsRefresh(c.MarketsRefresh)),
+		pipe.Fixture(apiConn, c.Languages, c.Fixtures, fixtureOpts...),
+		pipe.Player(apiConn, c.Languages, cache, pipe.WithCachePolicy(c.PlayerCache)),
+	)
+	if c.Lexicon != nil {
+		if c.LexiconFetch {
+			c.Lexicon.FetchFrom(apiConn)
+		}
+		stages = append(stages, c.Lexicon.Stage())
 	}
+	stages = append(stages, pipe.BetStop())
 	if len(c.Recovery) > 0 {
 		stages = append(stages, pipe.Recovery(apiConn, c.Recovery))
 	}
@@ -86,8 +124,10 @@ func firstErr(errc <-chan error, errorListener ErrorListenerFunc) error {
 func config(options ...Option) Config {
 	// defaults
 	c := &Config{
-		Languages: defaultLanguages,
-		Env:       uof.Production,
+		Languages:          defaultLanguages,
+		Env:                uof.Production,
+		TokenExpiryWarning: defaultTokenExpiryWarning,
+		MarketsRefresh:     defaultMarketsRefresh,
 	}
 	for _, o := range options {
 		o(c)
@@ -97,17 +137,78 @@ func config(options ...Option) Config {
 
 // connect to the queue and api
 func connect(ctx context.Context, c Config) (*queue.Connection, *api.API, error) {
-	conn, err := queue.Dial(ctx, c.Env, c.BookmakerID, c.Token)
+	stg, err := api.Dial(ctx, c.Env, c.Token, c.APIOptions...)
 	if err != nil {
 		return nil, nil, err
 	}
-	stg, err := api.Dial(ctx, c.Env, c.Token)
+	// details are fetched on dial, no need for another call
+	if err := checkBookmaker(stg.LastBookmakerDetails(), c); err != nil {
+		return nil, nil, err
+	}
+	conn, err := queue.Dial(ctx, c.Env, c.BookmakerID, c.Token)
 	if err != nil {
 		return nil, nil, err
 	}
 	return conn, stg, nil
 }
 
+// checkBookmaker validates that the token belongs to the configured bookmaker.
+func checkBookmaker(bd *uof.BookmakerDetails, c Config) error {
+	if bd == nil || c.BookmakerID == "" {
+		return nil
+	}
+	if c.BookmakerID != strconv.Itoa(bd.BookmakerID) {
+		return uof.Notice("bookmaker details",
+			fmt.Errorf("token issued for bookmaker %d, configured bookmaker %s", bd.BookmakerID, c.BookmakerID))
+	}
+	return nil
+}
+
+// tokenExpiry raises notice if the access token expires within warning.
+// Checks details from the api dial on start, and then periodically until ctx
+// is done or the pipe is closed.
+func tokenExpiry(ctx context.Context, a *api.API, warning time.Duration) pipe.InnerStage {
+	return pipe.StageWithSubProcessesSync(func(in <-chan *uof.Message, out chan<- *uof.Message, errc chan<- error) *sync.WaitGroup {
+		var wg sync.WaitGroup
+		done := make(chan struct{})
+		check := func(bd *uof.BookmakerDetails) {
+			if bd != nil && bd.ExpiresWithin(warning) {
+				errc <- uof.Notice("bookmaker details",
+					fmt.Errorf("access token expires at %s", bd.ExpireAt.Format(time.RFC3339)))
+			}
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			check(a.LastBookmakerDetails())
+			t := time.NewTicker(tokenExpiryCheckInterval)
+			defer t.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-done:
+					return
+				case <-t.C:
+					bd, err := a.BookmakerDetails()
+					if err != nil {
+						errc <- err
+						continue
+					}
+					check(bd)
+				}
+			}
+		}()
+
+		for m := range in {
+			out <- m
+		}
+		close(done)
+		return &wg
+	})
+}
+
 // Credentials for establishing connection to the uof queue and api.
 func Credentials(bookmakerID, token string) Option {
 	return func(c *Config) {
@@ -206,6 +307,97 @@ func Fixtures(to time.Time) Option {
 	}
 }
 
+// HTTPClient configures http client used for api calls.
+//
+// Sets transport, proxy, retry policy, request timeout or middleware. For
+// example to log each api request:
+//
+//	sdk.HTTPClient(api.WithMiddleware(api.Observe(func(ci api.CallInfo) {
+//	  log.Printf("%s %s %d %s", ci.Method, ci.URL, ci.StatusCode, ci.Duration)
+//	})))
+//
+// Can be called multiple times.
+func HTTPClient(opts ...api.Option) Option {
+	return func(c *Config) {
+		c.APIOptions = append(c.APIOptions, opts...)
+	}
+}
+
+// Lexicon fills store with the markets, fixtures and players from the
+// pipeline.
+//
+// Store is filled before messages reach consumers so lookups from consumers
+// find descriptions which are already received. If fetchOnMiss is set
+// lookup which misses the store makes blocking api call.
+func Lexicon(s *lexicon.Store, fetchOnMiss bool) Option {
+	return func(c *Config) {
+		c.Lexicon = s
+		c.LexiconFetch = fetchOnMiss
+	}
+}
+
+// LexiconCache keeps markets, fixtures and players in the root directory.
+//
+// On start stages load lexicon messages from the cache and call api only for
+// entries requested more than ttl ago. Messages loaded from the cache are
+// sent downstream as they were received from api.
+func LexiconCache(root string, ttl time.Duration) Option {
+	return func(c *Config) {
+		c.LexiconCache = pipe.NewDiskCache(root, ttl)
+	}
+}
+
+// HoldUntilFixture holds messages of the event, for which fixture is not yet
+// received, until the fixture is emitted. Consumers then always get the
+// fixture before the odds.
+func HoldUntilFixture() Option {
+	return func(c *Config) {
+		c.HoldUntilFixture = true
+	}
+}
+
+// MarketsRefresh sets interval of the all markets refresh. On refresh
+// markets message with only changed markets is emitted, MarketsDiff describes
+// the changes. Default is 1 hour, zero disables refresh.
+func MarketsRefresh(interval time.Duration) Option {
+	return func(c *Config) {
+		c.MarketsRefresh = interval
+	}
+}
+
+// MarketsCache sets policy of the in memory cache of the requested market
+// descriptions. Default TTL is 24 hours.
+func MarketsCache(p pipe.CachePolicy) Option {
+	return func(c *Config) {
+		c.MarketsCache = p
+	}
+}
+
+// FixtureCache sets policy of the in memory cache of the requested fixtures.
+// Default TTL is 1 minute.
+func FixtureCache(p pipe.CachePolicy) Option {
+	return func(c *Config) {
+		c.FixtureCache = p
+	}
+}
+
+// PlayerCache sets policy of the in memory cache of the requested players.
+// Default TTL is 1 hour.
+func PlayerCache(p pipe.CachePolicy) Option {
+	return func(c *Config) {
+		c.PlayerCache = p
+	}
+}
+
+// TokenExpiryWarning sets how long before the access token expiry to start
+// raising notices. Checked on startup and then every 12 hours. Default is 7
+// days, zero disables the check.
+func TokenExpiryWarning(d time.Duration) Option {
+	return func(c *Config) {
+		c.TokenExpiryWarning = d
+	}
+}
+
 // ListenErrors sets ErrorListener for all SDK errors
 func ListenErrors(listener ErrorListenerFunc) Option {
 	return func(c *Config) {
@@ -0,0 +1,327 @@
+package uof
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Specifiers of the market line, key value pairs from the specifiers (and
+// extended_specifiers) attribute.
+type Specifiers map[string]string
+
+// String returns canonical form of the specifiers: sorted by key, in the same
+// key=value|key=value format as in the feed.
+func (s Specifiers) String() string {
+	if len(s) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte('|')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(s[k])
+	}
+	return b.String()
+}
+
+// canonicalSpecifiers sorts specifiers string by key. Values are kept as in
+// the feed (player specifier keeps sr:player: prefix).
+func canonicalSpecifiers(specifiers string) string {
+	if specifiers == "" {
+		return ""
+	}
+	p := strings.Split(specifiers, "|")
+	key := func(s string) string {
+		return strings.SplitN(s, "=", 2)[0]
+	}
+	sort.SliceStable(p, func(i, j int) bool {
+		return key(p[i]) < key(p[j])
+	})
+	return strings.Join(p, "|")
+}
+
+// LineKey uniquely identifies market line. Unlike LineID it is not hash, so
+// there is no collisions between lines, and it can be converted back to the
+// specifiers.
+type LineKey struct {
+	MarketID int `json:"marketID"`
+	// canonical specifiers string of the line
+	Specifiers string `json:"specifiers,omitempty"`
+}
+
+func (k LineKey) String() string {
+	if k.Specifiers == "" {
+		return strconv.Itoa(k.MarketID)
+	}
+	return strconv.Itoa(k.MarketID) + "/" + k.Specifiers
+}
+
+// OutcomeKey uniquely identifies outcome of the market line. Outcome is
+// original outcome id from the feed (1, sr:player:123,
+// pre:outcometext:5...).
+type OutcomeKey struct {
+	Line    LineKey `json:"line"`
+	Outcome string  `json:"outcome"`
+}
+
+func (k OutcomeKey) String() string {
+	return k.Line.String() + "/" + k.Outcome
+}
+
+func (m Market) LineKey() LineKey {
+	return LineKey{MarketID: m.ID, Specifiers: m.LineSpecifiers}
+}
+
+func (m Market) OutcomeKey(o Outcome) OutcomeKey {
+	return OutcomeKey{Line: m.LineKey(), Outcome: outcomeURN(o.ID, o.URN)}
+}
+
+func (m BetSettlementMarket) LineKey() LineKey {
+	return LineKey{MarketID: m.ID, Specifiers: m.LineSpecifiers}
+}
+
+func (m BetSettlementMarket) OutcomeKey(o BetSettlementOutcome) OutcomeKey {
+	return OutcomeKey{Line: m.LineKey(), Outcome: outcomeURN(o.ID, o.URN)}
+}
+
+func (m BetCancelMarket) LineKey() LineKey {
+	return LineKey{MarketID: m.ID, Specifiers: m.LineSpecifiers}
+}
+
+func outcomeURN(id int, urn string) string {
+	if urn != "" {
+		return urn
+	}
+	return strconv.Itoa(id)
+}
+
+// toOutcomeURN keeps original outcome id when it is not plain integer, when
+// outcome id is hash or extracted player id.
+func toOutcomeURN(id string) string {
+	if _, err := strconv.ParseInt(id, 10, 64); err == nil {
+		return ""
+	}
+	return id
+}
+
+// Hash registry maps hashed ids (LineID, outcome and variant ids) back to the
+// original strings. It is disabled by default because it holds every hashed
+// string for the life of the process.
+var hashes = &hashRegistry{
+	values:   make(map[int]string),
+	collided: make(map[string]bool),
+}
+
+type hashRegistry struct {
+	enabled    int32
+	values     map[int]string
+	collisions []HashCollision
+	collided   map[string]bool
+	sync.RWMutex
+}
+
+// HashCollision two different strings with the same hash.
+type HashCollision struct {
+	Hash   int    `json:"hash"`
+	First  string `json:"first"`
+	Second string `json:"second"`
+}
+
+// EnableHashRegistry starts recording all hashed ids, so they can be
+// converted back with Unhash.
+func EnableHashRegistry() {
+	atomic.StoreInt32(&hashes.enabled, 1)
+}
+
+// Unhash returns original string for the hashed id. Works only for ids
+// hashed after EnableHashRegistry.
+func Unhash(h int) (string, bool) {
+	hashes.RLock()
+	defer hashes.RUnlock()
+	s, ok := hashes.values[h]
+	return s, ok
+}
+
+// HashCollisions returns collisions found since EnableHashRegistry. Registry
+// keeps first string for the collided hash.
+func HashCollisions() []HashCollision {
+	hashes.RLock()
+	defer hashes.RUnlock()
+	return append([]HashCollision(nil), hashes.collisions...)
+}
+
+func (r *hashRegistry) put(h int, s string) {
+	if atomic.LoadInt32(&r.enabled) == 0 {
+		return
+	}
+	r.RLock()
+	v, ok := r.values[h]
+	r.RUnlock()
+	if ok && v == s {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+	v, ok = r.values[h]
+	if !ok {
+		r.values[h] = s
+		return
+	}
+	if v != s && !r.collided[s] {
+		r.collided[s] = true
+		r.collisions = append(r.collisions, HashCollision{Hash: h, First: v, Second: s})
+	}
+}
+
+// Handicap value of the hcp specifier.
+// Asian handicap quarter lines (0.25, -0.75) are split into two half bets on
+// the neighbouring lines. Score handicap (1:0, used in 3-way handicap markets)
+// is head start of the home or away team.
+type Handicap struct {
+	// for the score handicap home minus away
+	Value float64 `json:"value"`
+	// set for the quarter lines: 0.25 is split into 0 and 0.5
+	Split []float64      `json:"split,omitempty"`
+	Score *ScoreHandicap `json:"score,omitempty"`
+}
+
+type ScoreHandicap struct {
+	Home int `json:"home"`
+	Away int `json:"away"`
+}
+
+// Quarter reports whether handicap is asian quarter line.
+func (h Handicap) Quarter() bool {
+	return len(h.Split) == 2
+}
+
+// Int value of the integer specifier.
+func (s Specifiers) Int(name string) (int, error) {
+	v, ok := s[name]
+	if !ok {
+		return 0, fmt.Errorf("specifier %s not found", name)
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("specifier %s=%s is not integer", name, v)
+	}
+	return i, nil
+}
+
+// Decimal value of the decimal specifier.
+func (s Specifiers) Decimal(name string) (float64, error) {
+	v, ok := s[name]
+	if !ok {
+		return 0, fmt.Errorf("specifier %s not found", name)
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("specifier %s=%s is not decimal", name, v)
+	}
+	return f, nil
+}
+
+// Handicap parses hcp specifier.
+func (s Specifiers) Handicap() (Handicap, error) {
+	v, ok := s["hcp"]
+	if !ok {
+		return Handicap{}, fmt.Errorf("specifier hcp not found")
+	}
+	if p := strings.Split(v, ":"); len(p) == 2 {
+		home, err1 := strconv.Atoi(p[0])
+		away, err2 := strconv.Atoi(p[1])
+		if err1 != nil || err2 != nil || home < 0 || away < 0 {
+			return Handicap{}, fmt.Errorf("specifier hcp=%s is not score handicap", v)
+		}
+		return Handicap{
+			Value: float64(home - away),
+			Score: &ScoreHandicap{Home: home, Away: away},
+		}, nil
+	}
+	f, err := s.Decimal("hcp")
+	if err != nil {
+		return Handicap{}, err
+	}
+	h := Handicap{Value: f}
+	if q := f * 4; q == math.Trunc(q) && math.Mod(q, 2) != 0 {
+		// quarter line, half of the stake goes on each neighbouring line
+		h.Split = []float64{f - 0.25, f + 0.25}
+	}
+	return h, nil
+}
+
+// Validate checks that all specifiers from the market description are
+// present and that values match the specifier types.
+func (s Specifiers) Validate(md *MarketDescription) error {
+	if md == nil {
+		return nil
+	}
+	for _, ms := range md.Specifiers {
+		v, ok := s[ms.Name]
+		if !ok {
+			return fmt.Errorf("market %d specifier %s not found", md.ID, ms.Name)
+		}
+		var err error
+		switch ms.Type {
+		case SpecifierTypeInteger:
+			_, err = s.Int(ms.Name)
+		case SpecifierTypeDecimal:
+			_, err = s.Decimal(ms.Name)
+		case SpecifierTypeString, SpecifierTypeVariableText:
+			if v == "" {
+				err = fmt.Errorf("specifier %s is empty", ms.Name)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("market %d %s", md.ID, err)
+		}
+	}
+	return nil
+}
+
+func (m Market) Int(name string) (int, error) {
+	return m.Specifiers.Int(name)
+}
+
+func (m Market) Decimal(name string) (float64, error) {
+	return m.Specifiers.Decimal(name)
+}
+
+func (m Market) Handicap() (Handicap, error) {
+	return m.Specifiers.Handicap()
+}
+
+// Validate checks market specifiers against the market description.
+func (m Market) Validate(md *MarketDescription) error {
+	return m.Specifiers.Validate(md)
+}
+
+func (m BetSettlementMarket) Int(name string) (int, error) {
+	return m.Specifiers.Int(name)
+}
+
+func (m BetSettlementMarket) Decimal(name string) (float64, error) {
+	return m.Specifiers.Decimal(name)
+}
+
+func (m BetSettlementMarket) Handicap() (Handicap, error) {
+	return m.Specifiers.Handicap()
+}
+
+// Validate checks market specifiers against the market description.
+func (m BetSettlementMarket) Validate(md *MarketDescription) error {
+	return m.Specifiers.Validate(md)
+}
@@ -0,0 +1,150 @@
+package uof
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecifiersString(t *testing.T) {
+	s := Specifiers{"total": "1.5", "variant": "sr:exact_goals:4+", "from": "1", "to": "15"}
+	assert.Equal(t, "from=1|to=15|total=1.5|variant=sr:exact_goals:4+", s.String())
+	assert.Equal(t, "", Specifiers(nil).String())
+
+	assert.Equal(t, "a=1|a1=2", canonicalSpecifiers("a1=2|a=1"))
+	assert.Equal(t, "goalnr=1|player=sr:player:122702", canonicalSpecifiers("player=sr:player:122702|goalnr=1"))
+	assert.Equal(t, "", canonicalSpecifiers(""))
+}
+
+func TestLineKey(t *testing.T) {
+	var m Market
+	buf := `<market id="18" specifiers="total=2.5|variant=sr:x" extended_specifiers="pero=2"><outcome id="sr:player:123" odds="1.5"/><outcome id="pre:outcometext:5" odds="2"/><outcome id="12" odds="3"/></market>`
+	assert.NoError(t, xml.Unmarshal([]byte(buf), &m))
+	assert.Equal(t, LineKey{MarketID: 18, Specifiers: "total=2.5|variant=sr:x"}, m.LineKey())
+	assert.Equal(t, "18/total=2.5|variant=sr:x", m.LineKey().String())
+	assert.Equal(t, "12", LineKey{MarketID: 12}.String())
+
+	// same line with specifiers in different order
+	var m2 Market
+	buf = `<market id="18" specifiers="variant=sr:x|total=2.5"/>`
+	assert.NoError(t, xml.Unmarshal([]byte(buf), &m2))
+	assert.Equal(t, m.LineKey(), m2.LineKey())
+
+	assert.Equal(t, "sr:player:123", m.OutcomeKey(m.Outcomes[0]).Outcome)
+	assert.Equal(t, 123, m.Outcomes[0].ID)
+	assert.Equal(t, "pre:outcometext:5", m.OutcomeKey(m.Outcomes[1]).Outcome)
+	assert.Equal(t, "12", m.OutcomeKey(m.Outcomes[2]).Outcome)
+	assert.Equal(t, "", m.Outcomes[2].URN)
+	assert.Equal(t, "18/total=2.5|variant=sr:x/pre:outcometext:5", m.OutcomeKey(m.Outcomes[1]).String())
+
+	var sm BetSettlementMarket
+	buf = `<market id="18" specifiers="variant=sr:x|total=2.5"><outcome id="sr:player:123" result="1"/></market>`
+	assert.NoError(t, xml.Unmarshal([]byte(buf), &sm))
+	assert.Equal(t, m.LineKey(), sm.LineKey())
+	assert.Equal(t, m.OutcomeKey(m.Outcomes[0]), sm.OutcomeKey(sm.Outcomes[0]))
+
+	var cm BetCancelMarket
+	buf = `<market id="18" specifiers="total=2.5|variant=sr:x"/>`
+	assert.NoError(t, xml.Unmarshal([]byte(buf), &cm))
+	assert.Equal(t, m.LineKey(), cm.LineKey())
+}
+
+func TestHashRegistry(t *testing.T) {
+	EnableHashRegistry()
+	defer func() {
+		hashes = &hashRegistry{values: make(map[int]string), collided: make(map[string]bool)}
+	}()
+
+	id := toLineID("total=2.5")
+	s, ok := Unhash(id)
+	assert.True(t, ok)
+	assert.Equal(t, "total=2.5", s)
+
+	id = toOutcomeID("pre:outcometext:5")
+	s, ok = Unhash(id)
+	assert.True(t, ok)
+	assert.Equal(t, "pre:outcometext:5", s)
+
+	_, ok = Unhash(1)
+	assert.False(t, ok)
+
+	// known fnv-1a 32 collision
+	assert.Equal(t, hash32("costarring"), hash32("liquid"))
+	hash32("liquid")
+	c := HashCollisions()
+	assert.Len(t, c, 1)
+	assert.Equal(t, HashCollision{Hash: hash32("costarring"), First: "costarring", Second: "liquid"}, c[0])
+	s, _ = Unhash(hash32("liquid"))
+	assert.Equal(t, "costarring", s)
+}
+
+func TestSpecifiersTyped(t *testing.T) {
+	m := Market{Specifiers: Specifiers{"total": "2.5", "periodnr": "2", "player": "123", "pero": "x"}}
+	f, err := m.Decimal("total")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, f)
+	i, err := m.Int("periodnr")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, i)
+	i, err = m.Int("player")
+	assert.NoError(t, err)
+	assert.Equal(t, 123, i)
+
+	_, err = m.Int("total")
+	assert.Error(t, err)
+	_, err = m.Decimal("pero")
+	assert.Error(t, err)
+	_, err = m.Decimal("missing")
+	assert.Error(t, err)
+	_, err = m.Handicap()
+	assert.Error(t, err)
+}
+
+func TestHandicap(t *testing.T) {
+	data := []struct {
+		hcp string
+		h   Handicap
+	}{
+		{"0", Handicap{}},
+		{"-1.5", Handicap{Value: -1.5}},
+		{"1", Handicap{Value: 1}},
+		{"0.25", Handicap{Value: 0.25, Split: []float64{0, 0.5}}},
+		{"-0.75", Handicap{Value: -0.75, Split: []float64{-1, -0.5}}},
+		{"1.75", Handicap{Value: 1.75, Split: []float64{1.5, 2}}},
+		{"1:0", Handicap{Value: 1, Score: &ScoreHandicap{Home: 1}}},
+		{"0:2", Handicap{Value: -2, Score: &ScoreHandicap{Away: 2}}},
+	}
+	for _, d := range data {
+		h, err := Specifiers{"hcp": d.hcp}.Handicap()
+		assert.NoError(t, err, d.hcp)
+		assert.Equal(t, d.h, h, d.hcp)
+	}
+	assert.True(t, Handicap{Value: 0.25, Split: []float64{0, 0.5}}.Quarter())
+
+	for _, hcp := range []string{"", "x", "1:x", "-1:0", "NaN"} {
+		_, err := Specifiers{"hcp": hcp}.Handicap()
+		assert.Error(t, err, hcp)
+	}
+}
+
+func TestSpecifiersValidate(t *testing.T) {
+	md := &MarketDescription{
+		ID: 575,
+		Specifiers: []MarketSpecifier{
+			{Name: "total", Type: SpecifierTypeDecimal},
+			{Name: "periodnr", Type: SpecifierTypeInteger},
+			{Name: "variant", Type: SpecifierTypeVariableText},
+		},
+	}
+	s := Specifiers{"total": "2.5", "periodnr": "1", "variant": "sr:x", "extended": "1"}
+	assert.NoError(t, s.Validate(md))
+	assert.NoError(t, Market{Specifiers: s}.Validate(md))
+	assert.NoError(t, s.Validate(nil))
+
+	s["periodnr"] = "1.5"
+	assert.EqualError(t, s.Validate(md), "market 575 specifier periodnr=1.5 is not integer")
+	delete(s, "periodnr")
+	assert.EqualError(t, s.Validate(md), "market 575 specifier periodnr not found")
+	assert.Error(t, BetSettlementMarket{Specifiers: s}.Validate(md))
+}
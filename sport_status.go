@@ -0,0 +1,333 @@
+package uof
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Betradar sport ids of the sports with the typed status view.
+// Reference: /v1/sports/en/sports.xml
+const (
+	SportSoccer   = 1
+	SportBaseball = 3
+	SportTennis   = 5
+	SportSnooker  = 19
+	SportCricket  = 21
+	SportDarts    = 22
+	SportBowls    = 32
+)
+
+// Sport specific views of the SportEventStatus. SportEventStatus has all
+// attributes for all sports, views have only attributes used in the sport
+// with the missing values set to zero.
+
+type Score struct {
+	Home int `json:"home"`
+	Away int `json:"away"`
+}
+
+// PeriodResult score of one period (set, inning, frame...).
+type PeriodResult struct {
+	Number int `json:"number"`
+	// match status code of the period, /v1/descriptions/en/match_status.xml
+	MatchStatusCode int   `json:"matchStatusCode"`
+	Score           Score `json:"score"`
+}
+
+// LiveStatus attributes common for all sports.
+type LiveStatus struct {
+	Status      EventStatus `json:"status"`
+	MatchStatus int         `json:"matchStatus"`
+	Score       Score       `json:"score"`
+	Reporting   bool        `json:"reporting,omitempty"`
+}
+
+type SoccerStatus struct {
+	LiveStatus
+	Periods   []PeriodResult `json:"periods,omitempty"`
+	Penalties *Score         `json:"penalties,omitempty"`
+	// played time, and stoppage time when in stoppage
+	MatchTime             time.Duration `json:"matchTime,omitempty"`
+	StoppageTime          time.Duration `json:"stoppageTime,omitempty"`
+	StoppageTimeAnnounced time.Duration `json:"stoppageTimeAnnounced,omitempty"`
+	ClockStopped          bool          `json:"clockStopped,omitempty"`
+	YellowCards           Score         `json:"yellowCards"`
+	RedCards              Score         `json:"redCards"`
+	YellowRedCards        Score         `json:"yellowRedCards"`
+	Corners               Score         `json:"corners"`
+}
+
+// TennisStatus score is number of won sets, periods are games in each set.
+type TennisStatus struct {
+	LiveStatus
+	Sets []PeriodResult `json:"sets,omitempty"`
+	// points in the current game, 15 30 40 and 50 for advantage; tiebreak
+	// points in the tiebreak
+	Points   Score `json:"points"`
+	Tiebreak bool  `json:"tiebreak,omitempty"`
+	// zero when unknown
+	Server Team `json:"server,omitempty"`
+}
+
+type BaseballStatus struct {
+	LiveStatus
+	Innings []PeriodResult `json:"innings,omitempty"`
+	Balls   int            `json:"balls"`
+	Strikes int            `json:"strikes"`
+	Outs    int            `json:"outs"`
+	// occupied first, second and third base
+	Bases      [3]bool `json:"bases"`
+	HomeBatter int     `json:"homeBatter,omitempty"`
+	AwayBatter int     `json:"awayBatter,omitempty"`
+}
+
+type CricketStatus struct {
+	LiveStatus
+	InningsScores []PeriodResult `json:"inningsScores,omitempty"`
+	Innings       int            `json:"innings"`
+	Over          int            `json:"over"`
+	Delivery      int            `json:"delivery"`
+	Dismissals    Score          `json:"dismissals"`
+	PenaltyRuns   Score          `json:"penaltyRuns"`
+}
+
+// DartsStatus score is number of won sets (or legs in the legs only format).
+type DartsStatus struct {
+	LiveStatus
+	Sets  []PeriodResult `json:"sets,omitempty"`
+	Legs  Score          `json:"legs"`
+	Throw int            `json:"throw"`
+	Visit int            `json:"visit"`
+}
+
+// SnookerStatus score is number of won frames.
+type SnookerStatus struct {
+	LiveStatus
+	Frames        []PeriodResult `json:"frames,omitempty"`
+	RemainingReds int            `json:"remainingReds"`
+}
+
+type BowlsStatus struct {
+	LiveStatus
+	Ends           []PeriodResult `json:"ends,omitempty"`
+	CurrentEnd     int            `json:"currentEnd"`
+	RemainingBowls Score          `json:"remainingBowls"`
+}
+
+// View returns sport specific view of the status (*SoccerStatus,
+// *TennisStatus...). Nil for sports without view.
+func (s *SportEventStatus) View(sportID int) interface{} {
+	if s == nil {
+		return nil
+	}
+	switch sportID {
+	case SportSoccer:
+		return s.Soccer()
+	case SportTennis:
+		return s.Tennis()
+	case SportBaseball:
+		return s.Baseball()
+	case SportCricket:
+		return s.Cricket()
+	case SportDarts:
+		return s.Darts()
+	case SportSnooker:
+		return s.Snooker()
+	case SportBowls:
+		return s.Bowls()
+	}
+	return nil
+}
+
+func (s *SportEventStatus) live() LiveStatus {
+	l := LiveStatus{
+		Status:      s.Status,
+		MatchStatus: intVal(s.MatchStatus),
+		Score:       Score{Home: intVal(s.HomeScore), Away: intVal(s.AwayScore)},
+	}
+	if s.Reporting != nil {
+		l.Reporting = *s.Reporting == EventReportingActive
+	}
+	return l
+}
+
+func (s *SportEventStatus) Soccer() *SoccerStatus {
+	if s == nil {
+		return nil
+	}
+	v := &SoccerStatus{
+		LiveStatus: s.live(),
+		Periods:    s.periods(),
+	}
+	if s.HomePenaltyScore != nil || s.AwayPenaltyScore != nil {
+		v.Penalties = &Score{Home: intVal(s.HomePenaltyScore), Away: intVal(s.AwayPenaltyScore)}
+	}
+	if c := s.Clock; c != nil {
+		v.MatchTime = clockDuration(c.MatchTime)
+		v.StoppageTime = clockDuration(c.StoppageTime)
+		v.StoppageTimeAnnounced = clockDuration(c.StoppageTimeAnnounced)
+		v.ClockStopped = boolVal(c.Stopped)
+	}
+	if st := s.Statistics; st != nil {
+		v.YellowCards = statisticsScore(st.YellowCards)
+		v.RedCards = statisticsScore(st.RedCards)
+		v.YellowRedCards = statisticsScore(st.YellowRedCards)
+		v.Corners = statisticsScore(st.Corners)
+	}
+	return v
+}
+
+func (s *SportEventStatus) Tennis() *TennisStatus {
+	if s == nil {
+		return nil
+	}
+	v := &TennisStatus{
+		LiveStatus: s.live(),
+		Sets:       s.periods(),
+		Points:     Score{Home: intVal(s.HomeGamescore), Away: intVal(s.AwayGamescore)},
+		Tiebreak:   boolVal(s.Tiebreak),
+	}
+	if s.CurrentServer != nil {
+		v.Server = *s.CurrentServer
+	}
+	return v
+}
+
+func (s *SportEventStatus) Baseball() *BaseballStatus {
+	if s == nil {
+		return nil
+	}
+	v := &BaseballStatus{
+		LiveStatus: s.live(),
+		Innings:    s.periods(),
+		Balls:      intVal(s.Balls),
+		Strikes:    intVal(s.Strikes),
+		Outs:       intVal(s.Outs),
+		HomeBatter: intVal(s.HomeBatter),
+		AwayBatter: intVal(s.AwayBatter),
+	}
+	if s.Bases != nil {
+		v.Bases = toBases(*s.Bases)
+	}
+	return v
+}
+
+func (s *SportEventStatus) Cricket() *CricketStatus {
+	if s == nil {
+		return nil
+	}
+	return &CricketStatus{
+		LiveStatus:    s.live(),
+		InningsScores: s.periods(),
+		Innings:       intVal(s.Innings),
+		Over:          intVal(s.Over),
+		Delivery:      intVal(s.Delivery),
+		Dismissals:    Score{Home: intVal(s.HomeDismissals), Away: intVal(s.AwayDismissals)},
+		PenaltyRuns:   Score{Home: intVal(s.HomePenaltyRuns), Away: intVal(s.AwayPenaltyRuns)},
+	}
+}
+
+func (s *SportEventStatus) Darts() *DartsStatus {
+	if s == nil {
+		return nil
+	}
+	return &DartsStatus{
+		LiveStatus: s.live(),
+		Sets:       s.periods(),
+		Legs:       Score{Home: intVal(s.HomeLegscore), Away: intVal(s.AwayLegscore)},
+		Throw:      intVal(s.Throw),
+		Visit:      intVal(s.Visit),
+	}
+}
+
+func (s *SportEventStatus) Snooker() *SnookerStatus {
+	if s == nil {
+		return nil
+	}
+	return &SnookerStatus{
+		LiveStatus:    s.live(),
+		Frames:        s.periods(),
+		RemainingReds: intVal(s.RemainingReds),
+	}
+}
+
+func (s *SportEventStatus) Bowls() *BowlsStatus {
+	if s == nil {
+		return nil
+	}
+	return &BowlsStatus{
+		LiveStatus:     s.live(),
+		Ends:           s.periods(),
+		CurrentEnd:     intVal(s.CurrentEnd),
+		RemainingBowls: Score{Home: intVal(s.HomeRemainingBowls), Away: intVal(s.AwayRemainingBowls)},
+	}
+}
+
+// periods sorted by number
+func (s *SportEventStatus) periods() []PeriodResult {
+	if len(s.PeriodScores) == 0 {
+		return nil
+	}
+	ps := make([]PeriodResult, 0, len(s.PeriodScores))
+	for _, p := range s.PeriodScores {
+		ps = append(ps, PeriodResult{
+			Number:          intVal(p.Number),
+			MatchStatusCode: intVal(p.MatchStatusCode),
+			Score:           Score{Home: intVal(p.HomeScore), Away: intVal(p.AwayScore)},
+		})
+	}
+	sort.SliceStable(ps, func(i, j int) bool { return ps[i].Number < ps[j].Number })
+	return ps
+}
+
+// toBases accepts occupancy flags for each base (0,1,0) or list of occupied
+// bases (2 or 1,3).
+func toBases(bases string) [3]bool {
+	var b [3]bool
+	p := strings.FieldsFunc(bases, func(r rune) bool { return r == ',' || r == '_' || r == '|' })
+	flags := len(p) == 3
+	for _, x := range p {
+		if x != "0" && x != "1" {
+			flags = false
+		}
+	}
+	for i, x := range p {
+		if flags {
+			b[i] = x == "1"
+			continue
+		}
+		if n, err := strconv.Atoi(x); err == nil && n >= 1 && n <= 3 {
+			b[n-1] = true
+		}
+	}
+	return b
+}
+
+// clockDuration zero if clock time is not set or invalid.
+func clockDuration(c *ClockTime) time.Duration {
+	d, _ := c.Duration()
+	return d
+}
+
+func statisticsScore(s *StatisticsScore) Score {
+	if s == nil {
+		return Score{}
+	}
+	return Score{Home: s.Home, Away: s.Away}
+}
+
+func intVal(p *int) int {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+func boolVal(p *bool) bool {
+	if p == nil {
+		return false
+	}
+	return *p
+}
@@ -0,0 +1,97 @@
+package uof
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSoccerStatus(t *testing.T) {
+	buf, err := ioutil.ReadFile("./testdata/odds_change-0.xml")
+	assert.NoError(t, err)
+	oc := &OddsChange{}
+	assert.NoError(t, xml.Unmarshal(buf, oc))
+
+	v := oc.EventStatus.Soccer()
+	assert.Equal(t, EventStatusLive, v.Status)
+	assert.Equal(t, 7, v.MatchStatus)
+	assert.True(t, v.Reporting)
+	assert.Equal(t, Score{2, 2}, v.Score)
+	assert.Equal(t, []PeriodResult{
+		{Number: 1, MatchStatusCode: 6, Score: Score{2, 0}},
+		{Number: 2, MatchStatusCode: 7, Score: Score{0, 2}},
+	}, v.Periods)
+	assert.Nil(t, v.Penalties)
+	assert.Equal(t, 75*time.Minute+2*time.Second, v.MatchTime)
+	assert.Equal(t, Score{1, 1}, v.YellowCards)
+	assert.Equal(t, Score{5, 7}, v.Corners)
+
+	assert.Equal(t, v, oc.EventStatus.View(SportSoccer))
+	assert.Nil(t, oc.EventStatus.View(2))
+	var s *SportEventStatus
+	assert.Nil(t, s.View(SportSoccer))
+	assert.Nil(t, s.Tennis())
+}
+
+func TestSportStatusViews(t *testing.T) {
+	parse := func(buf string) *SportEventStatus {
+		var s SportEventStatus
+		assert.NoError(t, xml.Unmarshal([]byte(buf), &s))
+		return &s
+	}
+
+	s := parse(`<sport_event_status status="1" match_status="9" home_score="1" away_score="0" current_server="2" home_gamescore="50" away_gamescore="40" tiebreak="false">
+  <period_scores>
+    <period_score match_status_code="9" number="2" home_score="3" away_score="2"/>
+    <period_score match_status_code="8" number="1" home_score="6" away_score="4"/>
+  </period_scores>
+</sport_event_status>`)
+	tennis := s.Tennis()
+	assert.Equal(t, Score{1, 0}, tennis.Score)
+	assert.Equal(t, Score{50, 40}, tennis.Points)
+	assert.Equal(t, TeamAway, tennis.Server)
+	assert.False(t, tennis.Tiebreak)
+	assert.Len(t, tennis.Sets, 2)
+	assert.Equal(t, 1, tennis.Sets[0].Number)
+	assert.Equal(t, Score{6, 4}, tennis.Sets[0].Score)
+
+	s = parse(`<sport_event_status status="1" match_status="401" home_score="2" away_score="3" balls="3" strikes="2" outs="1" bases="1,0,1" home_batter="12"/>`)
+	baseball := s.View(SportBaseball).(*BaseballStatus)
+	assert.Equal(t, 3, baseball.Balls)
+	assert.Equal(t, 2, baseball.Strikes)
+	assert.Equal(t, 1, baseball.Outs)
+	assert.Equal(t, [3]bool{true, false, true}, baseball.Bases)
+	assert.Equal(t, 12, baseball.HomeBatter)
+
+	s = parse(`<sport_event_status status="1" match_status="532" home_score="187" away_score="45" innings="2" over="7" delivery="3" home_dismissals="10" away_dismissals="2" away_penalty_runs="5"/>`)
+	cricket := s.Cricket()
+	assert.Equal(t, 2, cricket.Innings)
+	assert.Equal(t, 7, cricket.Over)
+	assert.Equal(t, 3, cricket.Delivery)
+	assert.Equal(t, Score{10, 2}, cricket.Dismissals)
+	assert.Equal(t, Score{0, 5}, cricket.PenaltyRuns)
+
+	s = parse(`<sport_event_status status="1" home_score="1" away_score="2" home_legscore="2" away_legscore="1" throw="2" visit="14"/>`)
+	darts := s.Darts()
+	assert.Equal(t, Score{2, 1}, darts.Legs)
+	assert.Equal(t, 14, darts.Visit)
+
+	s = parse(`<sport_event_status status="1" home_score="4" away_score="3" remaining_reds="6"/>`)
+	assert.Equal(t, 6, s.Snooker().RemainingReds)
+
+	s = parse(`<sport_event_status status="1" current_end="5" home_remaining_bowls="2" away_remaining_bowls="3"/>`)
+	assert.Equal(t, 5, s.Bowls().CurrentEnd)
+	assert.Equal(t, Score{2, 3}, s.Bowls().RemainingBowls)
+}
+
+func TestToBases(t *testing.T) {
+	assert.Equal(t, [3]bool{false, true, false}, toBases("0,1,0"))
+	assert.Equal(t, [3]bool{true, true, true}, toBases("1_1_1"))
+	assert.Equal(t, [3]bool{false, true, false}, toBases("2"))
+	assert.Equal(t, [3]bool{true, false, true}, toBases("1,3"))
+	assert.Equal(t, [3]bool{}, toBases(""))
+	assert.Equal(t, [3]bool{}, toBases("0,0,0"))
+}
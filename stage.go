@@ -0,0 +1,121 @@
+package uof
+
+import (
+	"sort"
+	"time"
+)
+
+// StageType type of the stage in the stages hierarchy.
+type StageType string
+
+const (
+	// meeting or race weekend, has races as children
+	StageTypeParent StageType = "parent"
+	StageTypeEvent  StageType = "event"
+	StageTypeRace   StageType = "race"
+	StageTypeSeason StageType = "season"
+)
+
+// Stage is sport event in which competitors race against each other (formula
+// 1, cycling, virtual dog and horse racing). Stages make a hierarchy: season
+// has meetings (parent stages), meetings have races.
+type Stage struct {
+	ID           int               `json:"id"`
+	URN          URN               `json:"urn"`
+	Name         string            `json:"name,omitempty"`
+	Type         StageType         `json:"type,omitempty"`
+	Status       string            `json:"status,omitempty"`
+	Scheduled    time.Time         `json:"scheduled,omitempty"`
+	ScheduledEnd time.Time         `json:"scheduledEnd,omitempty"`
+	Parent       *StageRef         `json:"parent,omitempty"`
+	Children     []StageRef        `json:"children,omitempty"`
+	Competitors  []StageCompetitor `json:"competitors,omitempty"`
+}
+
+// StageRef parent or child of the stage.
+type StageRef struct {
+	ID           int       `json:"id"`
+	URN          URN       `json:"urn"`
+	Name         string    `json:"name,omitempty"`
+	Type         StageType `json:"type,omitempty"`
+	Status       string    `json:"status,omitempty"`
+	Scheduled    time.Time `json:"scheduled,omitempty"`
+	ScheduledEnd time.Time `json:"scheduledEnd,omitempty"`
+}
+
+// StageCompetitor competitor of the race.
+type StageCompetitor struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Abbreviation string `json:"abbreviation,omitempty"`
+	CountryCode  string `json:"countryCode,omitempty"`
+	DrawNumber   int    `json:"drawNumber,omitempty"`
+}
+
+// Stage returns stage model of the stage fixture, nil for other fixtures.
+// Children are sorted by scheduled time, competitors by draw number.
+func (f Fixture) Stage() *Stage {
+	if !f.URN.IsStage() {
+		return nil
+	}
+	s := &Stage{
+		ID:           f.ID,
+		URN:          f.URN,
+		Name:         f.Name,
+		Type:         StageType(f.Type),
+		Status:       f.Status,
+		Scheduled:    f.Scheduled,
+		ScheduledEnd: f.ScheduledEnd,
+		Competitors:  toStageCompetitors(f.Competitors),
+	}
+	if p := f.Parent; p != nil {
+		s.Parent = &StageRef{
+			ID:           p.ID,
+			URN:          p.URN,
+			Name:         p.Name,
+			Type:         StageType(p.Type),
+			Scheduled:    p.Scheduled,
+			ScheduledEnd: p.ScheduledEnd,
+		}
+	}
+	for _, r := range f.Races {
+		s.Children = append(s.Children, StageRef{
+			ID:           r.ID,
+			URN:          r.URN,
+			Name:         r.Name,
+			Type:         StageType(r.Type),
+			Status:       r.Status,
+			Scheduled:    r.Scheduled,
+			ScheduledEnd: r.ScheduledEnd,
+		})
+	}
+	sort.SliceStable(s.Children, func(i, j int) bool {
+		return s.Children[i].Scheduled.Before(s.Children[j].Scheduled)
+	})
+	return s
+}
+
+func toStageCompetitors(cs []Competitor) []StageCompetitor {
+	if len(cs) == 0 {
+		return nil
+	}
+	scs := make([]StageCompetitor, 0, len(cs))
+	for _, c := range cs {
+		scs = append(scs, StageCompetitor{
+			ID:           c.ID,
+			Name:         c.Name,
+			Abbreviation: c.Abbreviation,
+			CountryCode:  c.CountryCode,
+			DrawNumber:   c.DrawNumber,
+		})
+	}
+	sort.SliceStable(scs, func(i, j int) bool {
+		// competitors without draw number are last
+		di, dj := scs[i].DrawNumber, scs[j].DrawNumber
+		if di == 0 || dj == 0 {
+			return di != 0 && dj == 0
+		}
+		return di < dj
+	})
+	return scs
+}
@@ -0,0 +1,65 @@
+package uof
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStageParent(t *testing.T) {
+	buf, err := ioutil.ReadFile("./testdata/fixture-6.xml")
+	assert.NoError(t, err)
+	m, err := NewAPIMessage(LangEN, MessageTypeFixture, buf)
+	assert.NoError(t, err)
+
+	s := m.Fixture.Stage()
+	assert.NotNil(t, s)
+	assert.Equal(t, URN("vdr:stage:1064551").EventID(), s.ID)
+	assert.Equal(t, StageTypeParent, s.Type)
+	assert.Nil(t, s.Parent)
+	assert.Len(t, s.Children, 2)
+	// sorted by scheduled
+	assert.Equal(t, "Race 1", s.Children[0].Name)
+	assert.Equal(t, URN("vdr:stage:1064552"), s.Children[0].URN)
+	assert.Equal(t, URN("vdr:stage:1064552").EventID(), s.Children[0].ID)
+	assert.Equal(t, "closed", s.Children[0].Status)
+	assert.Equal(t, "Race 2", s.Children[1].Name)
+
+	r := m.Fixture.Races[1]
+	assert.Len(t, r.Competitors, 2)
+	assert.Equal(t, 2, r.Competitors[0].DrawNumber)
+}
+
+func TestStageRace(t *testing.T) {
+	buf, err := ioutil.ReadFile("./testdata/fixture-7.xml")
+	assert.NoError(t, err)
+	m, err := NewAPIMessage(LangEN, MessageTypeFixture, buf)
+	assert.NoError(t, err)
+
+	s := m.Fixture.Stage()
+	assert.NotNil(t, s)
+	assert.Equal(t, URN("vdr:stage:1064551"), s.Parent.URN)
+	assert.Equal(t, "Hillside Park", s.Parent.Name)
+	assert.Len(t, s.Children, 0)
+	// by draw number, without number last
+	assert.Len(t, s.Competitors, 3)
+	assert.Equal(t, "Night Runner", s.Competitors[0].Name)
+	assert.Equal(t, 1, s.Competitors[0].DrawNumber)
+	assert.Equal(t, "Swift Arrow", s.Competitors[1].Name)
+	assert.Equal(t, "Lucky Star", s.Competitors[2].Name)
+	assert.Equal(t, 1203, s.Competitors[2].ID)
+}
+
+func TestStageNotStage(t *testing.T) {
+	buf, err := ioutil.ReadFile("./testdata/fixture-0.xml")
+	assert.NoError(t, err)
+	m, err := NewAPIMessage(LangEN, MessageTypeFixture, buf)
+	assert.NoError(t, err)
+	assert.Nil(t, m.Fixture.Stage())
+
+	assert.True(t, URN("sr:stage:1").IsStage())
+	assert.True(t, URN("vhc:stage:1").IsStage())
+	assert.False(t, URN("sr:match:1").IsStage())
+	assert.False(t, URN("").IsStage())
+}
@@ -0,0 +1,121 @@
+package uof
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseURN(t *testing.T) {
+	u, err := ParseURN("sr:competitor:42")
+	assert.NoError(t, err)
+	assert.Equal(t, URN("sr:competitor:42"), u)
+	assert.Equal(t, "sr", u.Prefix())
+	assert.Equal(t, "competitor", u.Type())
+	assert.Equal(t, 42, u.ID())
+
+	for _, s := range []string{"", "pero", "sr:match", ":match:1", "sr::1", "sr:match:pero", "sr:match:1:2"} {
+		_, err := ParseURN(s)
+		assert.Error(t, err, s)
+		assert.Equal(t, "", URN(s).Prefix(), s)
+		assert.Equal(t, "", URN(s).Type(), s)
+	}
+}
+
+func TestFromEventID(t *testing.T) {
+	for _, s := range []string{
+		"sr:match:123",
+		"sr:stage:127",
+		"sr:venue:255",
+		"vti:tournament:255",
+		"wns:draw:1221541870",
+	} {
+		id := URN(s).EventID()
+		u, err := FromEventID(id)
+		assert.NoError(t, err)
+		assert.Equal(t, URN(s), u)
+		assert.Equal(t, id, u.EventID())
+	}
+
+	_, err := FromEventID(0)
+	assert.Error(t, err)
+	_, err = FromEventID(-(1<<8 | 127))
+	assert.Error(t, err)
+}
+
+func TestURNUnknownType(t *testing.T) {
+	// unregistered types got id in the reserved range by hash of the type
+	id := URN("pero:zdero:255").EventID()
+	assert.True(t, id < 0)
+	suffix := -id & 0xff
+	assert.True(t, suffix >= minUnknownURNTypeSuffix && suffix <= maxUnknownURNTypeSuffix)
+	assert.Equal(t, id, URN("pero:zdero:255").EventID())
+	assert.Equal(t, 255, -id>>8)
+
+	// reading id doesn't register the type
+	_, err := FromEventID(id)
+	assert.Error(t, err)
+	assert.Equal(t, "", urnTypes.prefix(suffix))
+
+	// message with new urn type is parsed
+	m, err := NewQueueMessage("hi.pre.-.odds_change.1.pero:zdero.255", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, id, m.EventID)
+}
+
+func TestRegisterURNType(t *testing.T) {
+	assert.NoError(t, RegisterURNType("bm:match", 100))
+	assert.NoError(t, RegisterURNType("bm:match", 100))
+	assert.Equal(t, -(7<<8 | 100), URN("bm:match:7").EventID())
+	u, err := FromEventID(-(7<<8 | 100))
+	assert.NoError(t, err)
+	assert.Equal(t, URN("bm:match:7"), u)
+
+	assert.Error(t, RegisterURNType("bm:season", 100))
+	assert.Error(t, RegisterURNType("bm:match", 101))
+	assert.Error(t, RegisterURNType("sr:stage", 102))
+	assert.Error(t, RegisterURNType("bm:stage", 0))
+	assert.Error(t, RegisterURNType("bm:stage", 128))
+	assert.Error(t, RegisterURNType("bm", 103))
+}
+
+func TestURNMarshal(t *testing.T) {
+	type T struct {
+		Event      URN `xml:"event,attr" json:"event"`
+		Competitor URN `xml:"competitor" json:"competitor"`
+	}
+	v := T{Event: "sr:match:1", Competitor: "sr:competitor:2"}
+
+	buf, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"event":"sr:match:1","competitor":"sr:competitor:2"}`, string(buf))
+	var v2 T
+	assert.NoError(t, json.Unmarshal(buf, &v2))
+	assert.Equal(t, v, v2)
+
+	buf, err = xml.Marshal(v)
+	assert.NoError(t, err)
+	assert.Equal(t, `<T event="sr:match:1"><competitor>sr:competitor:2</competitor></T>`, string(buf))
+	var v3 T
+	assert.NoError(t, xml.Unmarshal(buf, &v3))
+	assert.Equal(t, v, v3)
+
+	// empty is valid
+	assert.NoError(t, json.Unmarshal([]byte(`{"event":""}`), &v2))
+	assert.Equal(t, NoURN, v2.Event)
+	buf, err = json.Marshal(T{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"event":"","competitor":""}`, string(buf))
+
+	// malformed is rejected
+	assert.Error(t, json.Unmarshal([]byte(`{"event":"sr:match"}`), &v2))
+	assert.Error(t, json.Unmarshal([]byte(`{"event":"sr:match:pero"}`), &v2))
+	assert.Error(t, xml.Unmarshal([]byte(`<T event="sr::1"></T>`), &v3))
+	assert.Error(t, xml.Unmarshal([]byte(`<T><competitor>competitor:2</competitor></T>`), &v3))
+	_, err = json.Marshal(T{Event: "sr:match:x"})
+	assert.Error(t, err)
+	_, err = xml.Marshal(T{Competitor: "2"})
+	assert.Error(t, err)
+}